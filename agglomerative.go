@@ -0,0 +1,236 @@
+package kmeans
+
+import (
+	"cmp"
+	"math"
+	"slices"
+)
+
+// Linkage defines the distance between two clusters used by Agglomerative
+type Linkage int
+
+const (
+	// SingleLinkage is the euclidean distance between the closest observations of the clusters
+	SingleLinkage Linkage = iota
+	// CompleteLinkage is the euclidean distance between the farthest observations of the clusters
+	CompleteLinkage
+	// AverageLinkage is the mean euclidean distance between the observations of the clusters
+	AverageLinkage
+	// WardLinkage merges the clusters that least increase the total within cluster variance
+	WardLinkage
+)
+
+// A Merge joins two clusters of a Dendrogram. Clusters below the number of observations n
+// are single observations in dataset order and cluster n+i is the cluster formed by Merges[i].
+type Merge struct {
+	// A and B are the clusters joined with A < B
+	A, B int
+	// Height is the linkage distance between A and B. For WardLinkage it is the square root
+	// of twice the increase in the within cluster sum of squares, so that merging two
+	// observations has a height equal to the euclidean distance between them.
+	Height float64
+	// Size is the number of observations in the merged cluster
+	Size int
+}
+
+// Dendrogram is the tree of merges found by Agglomerative
+type Dendrogram[T Number] struct {
+	// Merges holds the n-1 merges in order of increasing height
+	Merges []Merge
+	oo     []Observation[T]
+	degree int
+}
+
+// Agglomerative builds a hierarchical clustering of the dataset bottom up by repeatedly merging
+// the two clusters that are closest under the linkage. Merges are found with the nearest neighbor
+// chain algorithm and the Lance-Williams update of cluster distances. Average and Ward linkage
+// account for the ObservationWeight of each observation.
+func Agglomerative[T Number](dataset Observations[T], linkage Linkage) (*Dendrogram[T], error) {
+	degree := dataset.Degree()
+	if degree == 0 {
+		return nil, ErrEmptyObservations
+	}
+	var oo []Observation[T]
+	for o := range dataset.Observations() {
+		oo = append(oo, o)
+	}
+	n := len(oo)
+	dist := make([][]float64, n)
+	weights := make([]float64, n)
+	sizes := make([]int, n)
+	for i := range oo {
+		dist[i] = make([]float64, n)
+		for j := range i {
+			var d float64
+			if linkage == WardLinkage {
+				d = Distance(oo[i], oo[j], degree)
+			} else {
+				d = euclideanDistance(oo[i], oo[j], degree)
+			}
+			dist[i][j] = d
+			dist[j][i] = d
+		}
+		weights[i] = ObservationWeight(oo[i])
+		sizes[i] = 1
+	}
+
+	// Merged clusters are kept in the slot of A and the slot of B becomes inactive
+	active := make([]bool, n)
+	for i := range active {
+		active[i] = true
+	}
+	merges := make([]Merge, 0, max(n-1, 0))
+	var chain []int
+	for len(merges) < n-1 {
+		if len(chain) == 0 {
+			chain = append(chain, slices.Index(active, true))
+		}
+		a := chain[len(chain)-1]
+		// Prefer the previous cluster of the chain on ties so that the chain terminates
+		b := -1
+		bd := math.MaxFloat64
+		if len(chain) > 1 {
+			b = chain[len(chain)-2]
+			bd = dist[a][b]
+		}
+		for x := range active {
+			if active[x] && x != a && dist[a][x] < bd {
+				b = x
+				bd = dist[a][x]
+			}
+		}
+		if len(chain) < 2 || b != chain[len(chain)-2] {
+			chain = append(chain, b)
+			continue
+		}
+		chain = chain[:len(chain)-2]
+
+		for x := range active {
+			if !active[x] || x == a || x == b {
+				continue
+			}
+			d := linkage.update(dist[x][a], dist[x][b], bd, weights[a], weights[b], weights[x])
+			dist[x][a] = d
+			dist[a][x] = d
+		}
+		height := bd
+		if linkage == WardLinkage {
+			height = math.Sqrt(bd)
+		}
+		weights[a] += weights[b]
+		sizes[a] += sizes[b]
+		active[b] = false
+		merges = append(merges, Merge{A: a, B: b, Height: height, Size: sizes[a]})
+	}
+
+	// Order the merges by height and label clusters by the merge that formed them
+	slices.SortStableFunc(merges, func(m1, m2 Merge) int {
+		return cmp.Compare(m1.Height, m2.Height)
+	})
+	parents := newDisjointSet(n)
+	labels := make([]int, n)
+	for i := range labels {
+		labels[i] = i
+	}
+	for i, m := range merges {
+		ra, rb := parents.find(m.A), parents.find(m.B)
+		merges[i].A = min(labels[ra], labels[rb])
+		merges[i].B = max(labels[ra], labels[rb])
+		labels[parents.union(ra, rb)] = n + i
+	}
+	return &Dendrogram[T]{Merges: merges, oo: oo, degree: degree}, nil
+}
+
+// update returns the distance from cluster x to the union of clusters a and b using the
+// Lance-Williams formula for the linkage, where dab is the distance between a and b
+func (l Linkage) update(dxa, dxb, dab, wa, wb, wx float64) float64 {
+	switch l {
+	case CompleteLinkage:
+		return max(dxa, dxb)
+	case AverageLinkage:
+		return (wa*dxa + wb*dxb) / (wa + wb)
+	case WardLinkage:
+		return ((wa+wx)*dxa + (wb+wx)*dxb - wx*dab) / (wa + wb + wx)
+	default:
+		return min(dxa, dxb)
+	}
+}
+
+// CutK returns the k clusters formed by undoing the k-1 highest merges. The clusters are
+// ordered by their first observation and centered on the mean of their observations.
+func (d *Dendrogram[T]) CutK(k int) (Clusters[T], error) {
+	if k == 0 {
+		return nil, ErrKMustBeGreaterThanZero
+	}
+	if k > len(d.oo) {
+		return nil, ErrTooFewObservations
+	}
+	return d.cut(len(d.oo) - k), nil
+}
+
+// CutDistance returns the clusters formed by the merges no higher than threshold. The clusters
+// are ordered by their first observation and centered on the mean of their observations.
+func (d *Dendrogram[T]) CutDistance(threshold float64) Clusters[T] {
+	merges := 0
+	for merges < len(d.Merges) && d.Merges[merges].Height <= threshold {
+		merges++
+	}
+	return d.cut(merges)
+}
+
+// cut returns the clusters formed by the first merges
+func (d *Dendrogram[T]) cut(merges int) Clusters[T] {
+	n := len(d.oo)
+	parents := newDisjointSet(n)
+	// The leaves of cluster n+i
+	leaves := make([]int, merges)
+	leaf := func(cluster int) int {
+		if cluster < n {
+			return cluster
+		}
+		return leaves[cluster-n]
+	}
+	for i, m := range d.Merges[:merges] {
+		leaves[i] = parents.union(parents.find(leaf(m.A)), parents.find(leaf(m.B)))
+	}
+
+	var c Clusters[T]
+	cluster := make(map[int]int)
+	for i, o := range d.oo {
+		root := parents.find(i)
+		ci, ok := cluster[root]
+		if !ok {
+			ci = len(c)
+			cluster[root] = ci
+			c = append(c, Cluster[T]{Observations: NewObservationList[T](d.degree)})
+		}
+		c[ci].assign(o)
+	}
+	c.Recenter()
+	return c
+}
+
+// disjointSet is a union find over the integers below its length
+type disjointSet []int
+
+func newDisjointSet(n int) disjointSet {
+	s := make(disjointSet, n)
+	for i := range s {
+		s[i] = i
+	}
+	return s
+}
+
+func (s disjointSet) find(i int) int {
+	for s[i] != i {
+		s[i] = s[s[i]]
+		i = s[i]
+	}
+	return i
+}
+
+// union joins the sets with roots a and b and returns the root of the joined set
+func (s disjointSet) union(a, b int) int {
+	s[b] = a
+	return a
+}
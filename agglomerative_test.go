@@ -0,0 +1,68 @@
+package kmeans
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAgglomerative(t *testing.T) {
+	line := points{{0}, {1}, {5}, {6}, {20}}
+	for _, tc := range []struct {
+		linkage Linkage
+		heights []float64
+	}{
+		{SingleLinkage, []float64{1, 1, 4, 14}},
+		{CompleteLinkage, []float64{1, 1, 6, 20}},
+		{AverageLinkage, []float64{1, 1, 5, 17}},
+		{WardLinkage, []float64{1, 1, 5 * math.Sqrt2, math.Sqrt(2 * 4 * 1.0 / 5 * 17 * 17)}},
+	} {
+		d, err := Agglomerative(line, tc.linkage)
+		assert.NoError(t, err)
+		assert.Len(t, d.Merges, 4)
+		for i, m := range d.Merges {
+			assert.InDelta(t, tc.heights[i], m.Height, 1e-9)
+		}
+		assert.Equal(t, Merge{A: 0, B: 1, Height: 1, Size: 2}, d.Merges[0])
+		assert.Equal(t, Merge{A: 2, B: 3, Height: 1, Size: 2}, d.Merges[1])
+		assert.Equal(t, 5, d.Merges[2].A)
+		assert.Equal(t, 6, d.Merges[2].B)
+		assert.Equal(t, Merge{A: 4, B: 7, Height: d.Merges[3].Height, Size: 5}, d.Merges[3])
+
+		cc, err := d.CutK(2)
+		assert.NoError(t, err)
+		assert.Len(t, cc, 2)
+		assert.Len(t, cc[0].Observations.ClusterObservations, 4)
+		assert.Equal(t, 3.0, cc[0].Center.Values(0))
+		assert.Equal(t, 20.0, cc[1].Center.Values(0))
+
+		// Appending keeps the running mean of the observations already in the cluster
+		cc[0].Append(observationValues[float64]{8})
+		assert.Equal(t, 4.0, cc[0].Center.Values(0))
+
+		assert.Len(t, d.CutDistance(2), 3)
+		assert.Len(t, d.CutDistance(0.5), 5)
+		assert.Len(t, d.CutDistance(100), 1)
+
+		_, err = d.CutK(6)
+		assert.ErrorIs(t, err, ErrTooFewObservations)
+		_, err = d.CutK(0)
+		assert.ErrorIs(t, err, ErrKMustBeGreaterThanZero)
+	}
+
+	centers := [][]float64{{0, 0}, {10, 0}, {0, 10}}
+	pp := blobs(centers, 30, 1)
+	d, err := Agglomerative(pp, WardLinkage)
+	assert.NoError(t, err)
+	cc, err := d.CutK(3)
+	assert.NoError(t, err)
+	for i, center := range centers {
+		assert.Len(t, cc[i].Observations.ClusterObservations, 30)
+		assert.InDelta(t, center[0], cc[i].Center.Values(0), 0.5)
+		assert.InDelta(t, center[1], cc[i].Center.Values(1), 0.5)
+	}
+
+	_, err = Agglomerative(NewObservationList[float64](0), SingleLinkage)
+	assert.ErrorIs(t, err, ErrEmptyObservations)
+}
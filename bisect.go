@@ -0,0 +1,186 @@
+package kmeans
+
+import (
+	"fmt"
+	"math"
+	"slices"
+)
+
+var ErrCannotSplit = fmt.Errorf("not enough distinct observations to split into k clusters")
+
+// SplitCriterion selects which cluster Bisect splits next
+type SplitCriterion int
+
+const (
+	// SplitHighestSumOfDistance splits the cluster with the highest SumOfDistance
+	SplitHighestSumOfDistance SplitCriterion = iota
+	// SplitLargest splits the cluster with the most observations
+	SplitLargest
+)
+
+// BisectOptions controls the splits performed by Bisect
+type BisectOptions struct {
+	// Criterion selects the cluster to split next
+	Criterion SplitCriterion
+	// Trials is the number of 2-means attempts per split, the lowest variance split is kept
+	Trials int
+	// Fit controls the refinement of each 2-means attempt
+	Fit FitOptions
+}
+
+// DefaultBisectOptions are reasonable settings for most datasets. A zero Trials is
+// replaced with the default.
+var DefaultBisectOptions = BisectOptions{
+	Criterion: SplitHighestSumOfDistance,
+	Trials:    5,
+	Fit:       DefaultFitOptions,
+}
+
+// SplitNode is a cluster in the tree of splits built by Bisect. The root holds every
+// observation and each split node has two children that divide its observations.
+type SplitNode[T Number] struct {
+	Cluster  Cluster[T]
+	Parent   *SplitNode[T]
+	Children []*SplitNode[T]
+	// Index is the position of a leaf in the Clusters returned by Bisect or -1 for split nodes
+	Index int
+}
+
+// Leaf returns true when the node was not split
+func (n *SplitNode[T]) Leaf() bool {
+	return len(n.Children) == 0
+}
+
+// Bisect builds k clusters using bisecting k-means. Starting from a single cluster holding
+// every observation, the cluster selected by the criterion is split in two with 2-means
+// until there are k clusters. The leaves of the returned tree are the returned clusters.
+func Bisect[T Number](k int, dataset Observations[T], opts BisectOptions) (Clusters[T], *SplitNode[T], error) {
+	if dataset.Degree() == 0 {
+		return nil, nil, ErrEmptyObservations
+	}
+	if k == 0 {
+		return nil, nil, ErrKMustBeGreaterThanZero
+	}
+	if opts.Trials <= 0 {
+		opts.Trials = DefaultBisectOptions.Trials
+	}
+
+	root := &SplitNode[T]{Cluster: Cluster[T]{Observations: NewObservationList[T](dataset.Degree())}}
+	for o := range dataset.Observations() {
+		root.Cluster.assign(o)
+	}
+	if len(root.Cluster.Observations.ClusterObservations) == 0 {
+		return nil, nil, ErrEmptyObservations
+	}
+	root.Cluster.Recenter()
+
+	leaves := []*SplitNode[T]{root}
+	unsplittable := make(map[*SplitNode[T]]bool)
+	for len(leaves) < k {
+		i := nextSplit(leaves, unsplittable, opts.Criterion)
+		if i < 0 {
+			return nil, nil, ErrCannotSplit
+		}
+		halves, ok := splitCluster(leaves[i].Cluster, opts.Trials, opts.Fit)
+		if !ok {
+			unsplittable[leaves[i]] = true
+			continue
+		}
+		parent := leaves[i]
+		for _, h := range halves {
+			parent.Children = append(parent.Children, &SplitNode[T]{Cluster: h, Parent: parent})
+		}
+		leaves = slices.Replace(leaves, i, i+1, parent.Children...)
+	}
+
+	var c Clusters[T]
+	root.index(&c)
+	return c, root, nil
+}
+
+// index numbers the leaves in depth first order and appends their clusters to c
+func (n *SplitNode[T]) index(c *Clusters[T]) {
+	n.Index = -1
+	if n.Leaf() {
+		n.Index = len(*c)
+		*c = append(*c, n.Cluster)
+	}
+	for _, child := range n.Children {
+		child.index(c)
+	}
+}
+
+// nextSplit returns the index of the leaf to split next or -1 if none can be split
+func nextSplit[T Number](leaves []*SplitNode[T], unsplittable map[*SplitNode[T]]bool, criterion SplitCriterion) int {
+	next := -1
+	best := -math.MaxFloat64
+	for i, n := range leaves {
+		if unsplittable[n] || len(n.Cluster.Observations.ClusterObservations) < 2 {
+			continue
+		}
+		var score float64
+		switch criterion {
+		case SplitLargest:
+			score = float64(len(n.Cluster.Observations.ClusterObservations))
+		default:
+			score = n.Cluster.SumOfDistance()
+		}
+		if score > best {
+			best = score
+			next = i
+		}
+	}
+	return next
+}
+
+// splitCluster divides the observations of a cluster into two non-empty clusters using the
+// best of several 2-means attempts. It returns false if the cluster cannot be split.
+func splitCluster[T Number](cl Cluster[T], trials int, opts FitOptions) (Clusters[T], bool) {
+	var best Clusters[T]
+	variance := math.MaxFloat64
+	for range trials {
+		cc, err := NewWithInitializer(2, cl.Observations, KMeansPlusPlus[T])
+		if err != nil || len(cc) < 2 {
+			continue
+		}
+		if _, err := cc.Fit(cl.Observations, opts); err != nil {
+			continue
+		}
+		if len(cc[0].Observations.ClusterObservations) == 0 || len(cc[1].Observations.ClusterObservations) == 0 {
+			continue
+		}
+		if v := cc.SumClusterVariance(); v < variance {
+			variance = v
+			best = cc
+		}
+	}
+	return best, best != nil
+}
+
+// splitRounds splits every cluster in two and keeps the halves when split returns true,
+// refining the clusters with Fit after every round until no cluster is split or maxK is reached
+func splitRounds[T Number](cc Clusters[T], dataset Observations[T], maxK, trials int, fit FitOptions,
+	split func(cl Cluster[T], halves Clusters[T]) bool) (Clusters[T], error) {
+	for len(cc) < maxK {
+		var centers []Observation[T]
+		for i, cl := range cc {
+			// Keep room for one center for every cluster that has not been considered
+			room := maxK - len(centers) - (len(cc) - i)
+			if room > 0 {
+				if halves, ok := splitCluster(cl, trials, fit); ok && split(cl, halves) {
+					centers = append(centers, halves[0].Center, halves[1].Center)
+					continue
+				}
+			}
+			centers = append(centers, cl.Center)
+		}
+		if len(centers) == len(cc) {
+			break
+		}
+		cc = newClusters(dataset.Degree(), centers)
+		if _, err := cc.Fit(dataset, fit); err != nil {
+			return nil, err
+		}
+	}
+	return cc, nil
+}
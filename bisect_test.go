@@ -0,0 +1,41 @@
+package kmeans
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBisect(t *testing.T) {
+	oo := NormalizeObservations(listOfPeople())
+	for _, criterion := range []SplitCriterion{SplitHighestSumOfDistance, SplitLargest} {
+		for k := 1; k <= 6; k++ {
+			cc, root, err := Bisect(k, oo, BisectOptions{Criterion: criterion})
+			assert.NoError(t, err)
+			assert.Len(t, cc, k)
+			assert.Len(t, root.Cluster.Observations.ClusterObservations, len(listOfPeople()))
+			assertSplitTree(t, root, cc)
+		}
+	}
+
+	_, _, err := Bisect(0, oo, DefaultBisectOptions)
+	assert.ErrorIs(t, err, ErrKMustBeGreaterThanZero)
+	_, _, err = Bisect(len(listOfPeople())+1, oo, DefaultBisectOptions)
+	assert.ErrorIs(t, err, ErrCannotSplit)
+}
+
+func assertSplitTree[T Number](t *testing.T, n *SplitNode[T], cc Clusters[T]) {
+	if n.Leaf() {
+		assert.Equal(t, cc[n.Index].Observations, n.Cluster.Observations)
+		return
+	}
+	assert.Equal(t, -1, n.Index)
+	assert.Len(t, n.Children, 2)
+	size := 0
+	for _, child := range n.Children {
+		assert.Same(t, n, child.Parent)
+		size += len(child.Cluster.Observations.ClusterObservations)
+		assertSplitTree(t, child, cc)
+	}
+	assert.Equal(t, len(n.Cluster.Observations.ClusterObservations), size)
+}
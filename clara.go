@@ -0,0 +1,68 @@
+package kmeans
+
+import "math"
+
+// CLARAOptions controls the sampling performed by CLARA
+type CLARAOptions struct {
+	// Samples is the number of random samples clustered with KMedoids
+	Samples int
+	// SampleSize is the number of observations in each sample, 40+2k when zero
+	SampleSize int
+	// KMedoids controls the clustering of each sample
+	KMedoids KMedoidsOptions
+}
+
+// DefaultCLARAOptions are reasonable settings for most datasets. Zero values are
+// replaced with these defaults.
+var DefaultCLARAOptions = CLARAOptions{
+	Samples:  5,
+	KMedoids: DefaultKMedoidsOptions,
+}
+
+// CLARA (Clustering LARge Applications) clusters large datasets around k medoids. Each
+// sample is drawn with SelectRandomObservations and clustered with KMedoids. The medoids
+// of each sample are scored by the total euclidean distance of every observation in the
+// dataset to its nearest medoid and the best medoids are used to cluster the dataset.
+//
+// Only the samples are held in memory, while the dataset is streamed twice per sample.
+func CLARA[T Number](k int, dataset Observations[T], opts CLARAOptions) (Clusters[T], error) {
+	if dataset.Degree() == 0 {
+		return nil, ErrEmptyObservations
+	}
+	if k == 0 {
+		return nil, ErrKMustBeGreaterThanZero
+	}
+	if opts.Samples <= 0 {
+		opts.Samples = DefaultCLARAOptions.Samples
+	}
+	if opts.SampleSize <= 0 {
+		opts.SampleSize = 40 + 2*k
+	}
+
+	var medoids []Observation[T]
+	cost := math.MaxFloat64
+	for range opts.Samples {
+		sample := NewObservationList[T](dataset.Degree())
+		sample.ClusterObservations = SelectRandomObservations(dataset, opts.SampleSize)
+		cc, _, err := KMedoids(k, sample, opts.KMedoids)
+		if err != nil {
+			return nil, err
+		}
+		centers := cc.centers()
+		var sampleCost float64
+		for o := range dataset.Observations() {
+			_, d := nearestObservation(o, centers, dataset.Degree())
+			sampleCost += math.Sqrt(d)
+		}
+		if sampleCost < cost {
+			cost = sampleCost
+			medoids = centers
+		}
+	}
+
+	c := newClusters(dataset.Degree(), medoids)
+	for o := range dataset.Observations() {
+		c[c.Nearest(o)].assign(o)
+	}
+	return c, nil
+}
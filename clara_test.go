@@ -0,0 +1,25 @@
+package kmeans
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCLARA(t *testing.T) {
+	oo := NormalizeObservations(listOfPeople())
+	for k := 1; k <= 4; k++ {
+		cc, err := CLARA(k, oo, CLARAOptions{Samples: 3, SampleSize: 20})
+		assert.NoError(t, err)
+		assert.Len(t, cc, k)
+		n := 0
+		for _, cl := range cc {
+			assert.Contains(t, cl.Observations.ClusterObservations, cl.Center)
+			n += len(cl.Observations.ClusterObservations)
+		}
+		assert.Equal(t, len(listOfPeople()), n)
+	}
+
+	_, err := CLARA(30, oo, CLARAOptions{SampleSize: 20})
+	assert.ErrorIs(t, err, ErrTooFewObservations)
+}
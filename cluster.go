@@ -7,6 +7,7 @@ type Cluster[T Number] struct {
 	Center       Observation[T]
 	Observations *ObservationList[T]
 	sum          []float64
+	weight       float64
 }
 
 type centerObservation[T Number] []T
@@ -33,6 +34,7 @@ func (c *Cluster[T]) Reset() {
 	c.Observations.clear()
 	c.Center = nil
 	c.sum = nil
+	c.weight = 0
 }
 
 // Recenter updates the customer center a cluster
@@ -47,25 +49,49 @@ func (c *Cluster[T]) Recenter() {
 // Append adds an observation to the Cluster and recenters the cluster
 func (c *Cluster[T]) Append(o Observation[T]) {
 	c.Observations.Append(o)
+	c.weight += ObservationWeight(o)
+	c.addToMean(o, c.weight)
+}
+
+// addToMean adds o to the running weighted sum and moves the center to the mean of
+// the observations that have been added, whose weights total weight. The center does
+// not move while the total weight is 0.
+func (c *Cluster[T]) addToMean(o Observation[T], weight float64) {
 	if c.sum == nil {
 		c.sum = make([]float64, c.Observations.Degree())
 	}
+	if weight == 0 {
+		return
+	}
+	w := ObservationWeight(o)
 	center := make([]T, c.Observations.Degree())
-	l := len(c.Observations.ClusterObservations)
 	for i := range c.Observations.Degree() {
-		c.sum[i] += float64(o.Values(i))
-		center[i] = T(float64(c.sum[i] / float64(l)))
+		c.sum[i] += w * float64(o.Values(i))
+		center[i] = T(float64(c.sum[i] / weight))
 	}
 	c.Center = centerObservation[T](center)
 }
 
+// assign adds an observation to the Cluster without moving the center
+func (c *Cluster[T]) assign(o Observation[T]) {
+	c.Observations.Append(o)
+	if c.sum == nil {
+		c.sum = make([]float64, c.Observations.Degree())
+	}
+	w := ObservationWeight(o)
+	c.weight += w
+	for i := range c.Observations.Degree() {
+		c.sum[i] += w * float64(o.Values(i))
+	}
+}
+
 // SumOfDistance computes the sum of the distance of all the observations
-// from the center of the cluster
+// from the center of the cluster, scaled by their weight
 func (c *Cluster[T]) SumOfDistance() float64 {
 	var d float64
 	count := 0
 	for _, o := range c.Observations.ClusterObservations {
-		d += Distance(o, c.Center, c.Observations.d)
+		d += ObservationWeight(o) * Distance(o, c.Center, c.Observations.d)
 		count++
 	}
 	if count == 0 {
@@ -14,7 +14,14 @@ var ErrKMustBeGreaterThanZero = fmt.Errorf("k must be greater than 0")
 var ErrEmptyObservations error = fmt.Errorf("empty observation, there is no mean for an empty set of points")
 
 // New sets up a new set of clusters and randomly seeds their initial positions
+// with SelectWeightedObservations
 func New[T Number](k int, dataset Observations[T]) (Clusters[T], error) {
+	return NewWithInitializer(k, dataset, SelectWeightedObservations[T])
+}
+
+// NewWithInitializer sets up a new set of clusters and seeds their initial positions
+// with the provided Initializer (e.g. KMeansPlusPlus)
+func NewWithInitializer[T Number](k int, dataset Observations[T], init Initializer[T]) (Clusters[T], error) {
 	var c Clusters[T]
 	if dataset.Degree() == 0 {
 		return c, ErrEmptyObservations
@@ -23,13 +30,19 @@ func New[T Number](k int, dataset Observations[T]) (Clusters[T], error) {
 		return c, ErrKMustBeGreaterThanZero
 	}
 
-	for _, o := range SelectRandomObservations(dataset, k) {
+	return newClusters(dataset.Degree(), init(dataset, k)), nil
+}
+
+// newClusters creates an empty cluster around each of the centers
+func newClusters[T Number](degree int, centers []Observation[T]) Clusters[T] {
+	var c Clusters[T]
+	for _, o := range centers {
 		c = append(c, Cluster[T]{
-			Center:       Observation[T](o),
-			Observations: NewObservationList[T](dataset.Degree()),
+			Center:       o,
+			Observations: NewObservationList[T](degree),
 		})
 	}
-	return c, nil
+	return c
 }
 
 func SelectRandomObservations[T Number](oo Observations[T], k int) []Observation[T] {
@@ -39,14 +52,46 @@ func SelectRandomObservations[T Number](oo Observations[T], k int) []Observation
 		if count < len(roo) {
 			roo[count] = o
 		} else {
-			i := rand.Intn(count)
+			i := rand.Intn(count + 1)
 			if i < len(roo) {
 				roo[i] = o
 			}
 		}
 		count++
 	}
-	return roo
+	return roo[:min(count, k)]
+}
+
+// SelectWeightedObservations selects k distinct observations with probability proportional
+// to their ObservationWeight using weighted reservoir sampling. Observations with a weight of 0
+// are never selected. Without WeightedObservations it selects like SelectRandomObservations.
+func SelectWeightedObservations[T Number](oo Observations[T], k int) []Observation[T] {
+	selected := make([]Observation[T], 0, k)
+	keys := make([]float64, 0, k)
+	lowest := 0
+	for o := range oo.Observations() {
+		w := ObservationWeight(o)
+		if w <= 0 {
+			continue
+		}
+		// Keep the observations with the largest keys, log(u)/w
+		key := math.Log(rand.Float64()) / w
+		if len(selected) < k {
+			selected = append(selected, o)
+			keys = append(keys, key)
+		} else if key > keys[lowest] {
+			selected[lowest] = o
+			keys[lowest] = key
+		} else {
+			continue
+		}
+		for i := range keys {
+			if keys[i] < keys[lowest] {
+				lowest = i
+			}
+		}
+	}
+	return selected
 }
 
 // Nearest returns the index of the cluster nearest to point
@@ -132,6 +177,13 @@ func (c Clusters[T]) Smallest() int {
 // This function is exponential both in its computation of permutations for possible
 // cluster centers O(k*n*degree) in cluster matching.
 func OptimizeClusters[T Number](k int, dataset Observations[T]) (Clusters[T], error) {
+	return OptimizeClustersWithInitializer(k, dataset, nil)
+}
+
+// OptimizeClustersWithInitializer scores center sets drawn from the provided Initializer
+// (e.g. KMeansPlusPlus) and returns the clusters with the lowest variance. A nil Initializer
+// behaves like OptimizeClusters.
+func OptimizeClustersWithInitializer[T Number](k int, dataset Observations[T], init Initializer[T]) (Clusters[T], error) {
 	if dataset.Degree() == 0 {
 		return nil, ErrEmptyObservations
 	}
@@ -139,7 +191,7 @@ func OptimizeClusters[T Number](k int, dataset Observations[T]) (Clusters[T], er
 		return nil, ErrKMustBeGreaterThanZero
 	}
 
-	perm := permutations(dataset, k)
+	perm := permutations(dataset, k, init)
 
 	var optimalClusters Clusters[T]
 	sum := math.MaxFloat64
@@ -155,9 +207,12 @@ func OptimizeClusters[T Number](k int, dataset Observations[T]) (Clusters[T], er
 	return optimalClusters, nil
 }
 
-func permutations[T Number](dataset Observations[T], k int) [][]Observation[T] {
+func permutations[T Number](dataset Observations[T], k int, init Initializer[T]) [][]Observation[T] {
+	if init != nil {
+		return randomPermutations(dataset, k, init)
+	}
 	if k >= 3 {
-		return randomPermutations(dataset, k)
+		return randomPermutations(dataset, k, SelectWeightedObservations[T])
 	}
 	var ll []Observation[T]
 	for o := range dataset.Observations() {
@@ -166,10 +221,10 @@ func permutations[T Number](dataset Observations[T], k int) [][]Observation[T] {
 	return mapList(nil, ll, k)
 }
 
-func randomPermutations[T Number](dataset Observations[T], k int) [][]Observation[T] {
+func randomPermutations[T Number](dataset Observations[T], k int, init Initializer[T]) [][]Observation[T] {
 	var ll [][]Observation[T]
 	for range 1000 * k {
-		ll = append(ll, SelectRandomObservations(dataset, k))
+		ll = append(ll, init(dataset, k))
 	}
 	return ll
 }
@@ -0,0 +1,204 @@
+package kmeans
+
+import (
+	"fmt"
+	"iter"
+	"math"
+)
+
+var ErrInvalidCoresetSize = fmt.Errorf("coreset size must be greater than 0")
+
+// CoresetObservation is an observation of the dataset chosen to represent the observations
+// nearest to it. Its weight is the total weight of the observations it represents.
+type CoresetObservation[T Number] struct {
+	Observation[T]
+	weight float64
+}
+
+// Weight returns the total weight of the observations represented
+func (c CoresetObservation[T]) Weight() float64 {
+	return c.weight
+}
+
+// Coreset is a small weighted set of observations summarizing a larger dataset
+type Coreset[T Number] struct {
+	CoresetObservations []CoresetObservation[T]
+	d                   int
+}
+
+func (c *Coreset[T]) Observations() iter.Seq[Observation[T]] {
+	return func(yield func(Observation[T]) bool) {
+		for _, o := range c.CoresetObservations {
+			if !yield(o) {
+				return
+			}
+		}
+	}
+}
+
+func (c *Coreset[T]) Degree() int {
+	return c.d
+}
+
+// CoresetBuilder summarizes an unbounded stream of observations into a coreset using the
+// merge and reduce scheme of StreamKM++. Observations are buffered into buckets of the coreset
+// size. Whenever two buckets of the same level exist they are merged and reduced back to the
+// coreset size with k-means++ sampling, so at most one bucket per level is kept and memory grows
+// with the logarithm of the number of observations.
+type CoresetBuilder[T Number] struct {
+	size   int
+	degree int
+	// levels holds at most one reduced bucket for each level, nil when the level is empty
+	levels [][]CoresetObservation[T]
+	buffer []CoresetObservation[T]
+}
+
+// NewCoresetBuilder returns a builder for coresets of size observations with the degree
+func NewCoresetBuilder[T Number](size, degree int) (*CoresetBuilder[T], error) {
+	if degree == 0 {
+		return nil, ErrEmptyObservations
+	}
+	if size <= 0 {
+		return nil, ErrInvalidCoresetSize
+	}
+	return &CoresetBuilder[T]{
+		size:   size,
+		degree: degree,
+	}, nil
+}
+
+// Add adds an observation to the coreset. The observation is only retained if it is chosen
+// to represent other observations.
+func (b *CoresetBuilder[T]) Add(o Observation[T]) {
+	b.buffer = append(b.buffer, newCoresetObservation(o, ObservationWeight(o)))
+	if len(b.buffer) < b.size {
+		return
+	}
+	carry := b.buffer
+	b.buffer = nil
+	for level := 0; ; level++ {
+		if level == len(b.levels) {
+			b.levels = append(b.levels, nil)
+		}
+		if b.levels[level] == nil {
+			b.levels[level] = carry
+			return
+		}
+		carry = b.reduce(append(b.levels[level], carry...))
+		b.levels[level] = nil
+	}
+}
+
+// AddAll adds every observation in the sequence
+func (b *CoresetBuilder[T]) AddAll(oo iter.Seq[Observation[T]]) {
+	for o := range oo {
+		b.Add(o)
+	}
+}
+
+// Coreset returns the coreset of the observations added so far
+func (b *CoresetBuilder[T]) Coreset() *Coreset[T] {
+	all := append([]CoresetObservation[T](nil), b.buffer...)
+	for _, bucket := range b.levels {
+		all = append(all, bucket...)
+	}
+	return &Coreset[T]{
+		CoresetObservations: b.reduce(all),
+		d:                   b.degree,
+	}
+}
+
+// reduce selects the coreset size representatives from the observations with k-means++ and
+// weights each by the total weight of the observations nearest to it
+func (b *CoresetBuilder[T]) reduce(oo []CoresetObservation[T]) []CoresetObservation[T] {
+	if len(oo) <= b.size {
+		return oo
+	}
+	observations := make([]Observation[T], len(oo))
+	weights := make([]float64, len(oo))
+	for i, o := range oo {
+		observations[i] = o.Observation
+		weights[i] = o.weight
+	}
+	representatives := weightedKMeansPlusPlus(observations, weights, b.size, b.degree)
+	reduced := make([]CoresetObservation[T], len(representatives))
+	for i, r := range representatives {
+		reduced[i] = CoresetObservation[T]{Observation: r}
+	}
+	for i, o := range observations {
+		j, _ := nearestObservation(o, representatives, b.degree)
+		reduced[j].weight += weights[i]
+	}
+	return reduced
+}
+
+// newCoresetObservation wraps o with the weight, unwrapping o if it is already a CoresetObservation
+func newCoresetObservation[T Number](o Observation[T], weight float64) CoresetObservation[T] {
+	if c, ok := o.(CoresetObservation[T]); ok {
+		o = c.Observation
+	}
+	return CoresetObservation[T]{Observation: o, weight: weight}
+}
+
+// CoresetOptions controls the clustering performed by ClusterCoreset
+type CoresetOptions struct {
+	// Size is the number of observations in the coreset
+	Size int
+	// Trials is the number of k-means++ seeded clusterings of the coreset tried,
+	// the clustering with the lowest variance is kept
+	Trials int
+	// Fit controls the refinement of each clustering of the coreset
+	Fit FitOptions
+}
+
+// DefaultCoresetOptions are reasonable settings for most datasets. Zero values for
+// Size and Trials are replaced with these defaults.
+var DefaultCoresetOptions = CoresetOptions{
+	Size:   200,
+	Trials: 3,
+	Fit:    DefaultFitOptions,
+}
+
+// ClusterCoreset clusters an unbounded dataset by streaming it once into a coreset and
+// refining k clusters of the coreset with Fit, keeping the best of several trials. The
+// returned clusters hold the CoresetObservations, whose weights total the weight of the dataset.
+func ClusterCoreset[T Number](k int, dataset Observations[T], opts CoresetOptions) (Clusters[T], error) {
+	if k == 0 {
+		return nil, ErrKMustBeGreaterThanZero
+	}
+	if opts.Size <= 0 {
+		opts.Size = DefaultCoresetOptions.Size
+	}
+	if opts.Trials <= 0 {
+		opts.Trials = DefaultCoresetOptions.Trials
+	}
+	b, err := NewCoresetBuilder[T](opts.Size, dataset.Degree())
+	if err != nil {
+		return nil, err
+	}
+	b.AddAll(dataset.Observations())
+	coreset := b.Coreset()
+	if len(coreset.CoresetObservations) == 0 {
+		return nil, ErrEmptyObservations
+	}
+
+	var cc Clusters[T]
+	variance := math.MaxFloat64
+	for range opts.Trials {
+		trial, err := NewWithInitializer(k, coreset, KMeansPlusPlus[T])
+		if err != nil {
+			return nil, err
+		}
+		if len(trial) < k {
+			return nil, ErrTooFewObservations
+		}
+		if _, err := trial.Fit(coreset, opts.Fit); err != nil {
+			return nil, err
+		}
+		if v := trial.SumClusterVariance(); v < variance {
+			variance = v
+			cc = trial
+		}
+	}
+	return cc, nil
+}
@@ -0,0 +1,47 @@
+package kmeans
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCoresetBuilder(t *testing.T) {
+	pp := blobs([][]float64{{0, 0}, {10, 0}, {0, 10}}, 1000, 1)
+	b, err := NewCoresetBuilder[float64](50, 2)
+	assert.NoError(t, err)
+	b.AddAll(pp.Observations())
+	// Merge and reduce keeps at most one bucket per level
+	assert.LessOrEqual(t, len(b.levels), 7)
+
+	coreset := b.Coreset()
+	assert.Len(t, coreset.CoresetObservations, 50)
+	var weight float64
+	for o := range coreset.Observations() {
+		weight += ObservationWeight(o)
+		_, nested := o.(CoresetObservation[float64]).Observation.(CoresetObservation[float64])
+		assert.False(t, nested)
+	}
+	assert.InDelta(t, float64(len(pp)), weight, 1e-9)
+
+	_, err = NewCoresetBuilder[float64](0, 2)
+	assert.ErrorIs(t, err, ErrInvalidCoresetSize)
+}
+
+func TestClusterCoreset(t *testing.T) {
+	pp := blobs([][]float64{{0, 0}, {10, 0}, {0, 10}}, 1000, 1)
+	cc, err := ClusterCoreset(3, pp, CoresetOptions{Size: 100})
+	assert.NoError(t, err)
+	assert.Len(t, cc, 3)
+	for _, center := range [][]float64{{0, 0}, {10, 0}, {0, 10}} {
+		c := cc[cc.Nearest(observationValues[float64](center))]
+		assert.InDelta(t, center[0], c.Center.Values(0), 0.5)
+		assert.InDelta(t, center[1], c.Center.Values(1), 0.5)
+		assert.InDelta(t, 1000, c.weight, 50)
+	}
+
+	_, err = ClusterCoreset(3, NewObservationList[float64](2), DefaultCoresetOptions)
+	assert.ErrorIs(t, err, ErrEmptyObservations)
+	_, err = ClusterCoreset(3, points{{0, 0}, {1, 1}}, DefaultCoresetOptions)
+	assert.ErrorIs(t, err, ErrTooFewObservations)
+}
@@ -0,0 +1,82 @@
+package kmeans
+
+import "fmt"
+
+var ErrInvalidDensity = fmt.Errorf("eps and minPts must be greater than 0")
+
+// DBSCAN clusters the dataset by density. Observations with at least minPts observations,
+// including themselves, within euclidean distance eps are core observations. Core observations
+// within eps of each other share a cluster along with every observation within eps of one of
+// them. Observations that are not within eps of any core observation are returned as noise.
+// Clusters are numbered in the order their first core observation is found and are centered
+// on the mean of their observations.
+func DBSCAN[T Number](dataset Observations[T], eps float64, minPts int) (Clusters[T], *ObservationList[T], error) {
+	degree := dataset.Degree()
+	if degree == 0 {
+		return nil, nil, ErrEmptyObservations
+	}
+	if eps <= 0 || minPts <= 0 {
+		return nil, nil, ErrInvalidDensity
+	}
+	var oo []Observation[T]
+	for o := range dataset.Observations() {
+		oo = append(oo, o)
+	}
+	// Distance is squared so compare against the squared radius
+	radius := eps * eps
+	region := func(i int) []int {
+		var neighbors []int
+		for j, o := range oo {
+			if Distance(oo[i], o, degree) <= radius {
+				neighbors = append(neighbors, j)
+			}
+		}
+		return neighbors
+	}
+
+	const unvisited, noise = -2, -1
+	labels := make([]int, len(oo))
+	for i := range labels {
+		labels[i] = unvisited
+	}
+	var c Clusters[T]
+	for i := range oo {
+		if labels[i] != unvisited {
+			continue
+		}
+		neighbors := region(i)
+		if len(neighbors) < minPts {
+			labels[i] = noise
+			continue
+		}
+		ci := len(c)
+		c = append(c, Cluster[T]{Observations: NewObservationList[T](degree)})
+		labels[i] = ci
+		for len(neighbors) > 0 {
+			j := neighbors[0]
+			neighbors = neighbors[1:]
+			if labels[j] == noise {
+				// Border observation reached from a core observation
+				labels[j] = ci
+			}
+			if labels[j] != unvisited {
+				continue
+			}
+			labels[j] = ci
+			if expanded := region(j); len(expanded) >= minPts {
+				neighbors = append(neighbors, expanded...)
+			}
+		}
+	}
+
+	noiseList := NewObservationList[T](degree)
+	for i, o := range oo {
+		if labels[i] == noise {
+			noiseList.Append(o)
+		} else {
+			c[labels[i]].assign(o)
+		}
+	}
+	c.Recenter()
+	return c, noiseList, nil
+}
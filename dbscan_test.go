@@ -0,0 +1,45 @@
+package kmeans
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDBSCAN(t *testing.T) {
+	// Two rings that k-means cannot separate and a few isolated observations
+	pp := rings(100)
+	outliers := points{{20, 20}, {-20, 20}, {20, -20}}
+	pp = append(pp, outliers...)
+
+	cc, noise, err := DBSCAN(pp, 1.5, 4)
+	assert.NoError(t, err)
+	assert.Len(t, cc, 2)
+	assert.Len(t, noise.ClusterObservations, len(outliers))
+	for _, o := range outliers {
+		assert.Contains(t, noise.ClusterObservations, observationValues[float64](o))
+	}
+	for _, cl := range cc {
+		assert.Len(t, cl.Observations.ClusterObservations, 100)
+		// Both rings are centered on the origin
+		assert.InDelta(t, 0, cl.Center.Values(0), 1)
+		assert.InDelta(t, 0, cl.Center.Values(1), 1)
+	}
+
+	// Everything is noise when no observation has enough neighbors
+	cc, noise, err = DBSCAN(pp, 1e-6, 2)
+	assert.NoError(t, err)
+	assert.Empty(t, cc)
+	assert.Len(t, noise.ClusterObservations, len(pp))
+
+	_, _, err = DBSCAN(pp, 0, 4)
+	assert.ErrorIs(t, err, ErrInvalidDensity)
+	_, _, err = DBSCAN(pp, 1, 0)
+	assert.ErrorIs(t, err, ErrInvalidDensity)
+
+	// Appending keeps the running mean of the observations already in the cluster
+	cc, _, err = DBSCAN(points{{0, 0}, {1, 0}, {2, 0}}, 1.5, 2)
+	assert.NoError(t, err)
+	cc[0].Append(observationValues[float64]{3, 0})
+	assert.Equal(t, 1.5, cc[0].Center.Values(0))
+}
@@ -0,0 +1,85 @@
+package kmeans
+
+// fitElkan refines the clusters with Elkan's algorithm. Every observation keeps an upper
+// bound on the distance to its assigned center and a lower bound on the distance to each
+// other center. The bounds and the distances between centers are used with the triangle
+// inequality to skip distance computations that cannot change the assignment.
+func (c Clusters[T]) fitElkan(dataset Observations[T], opts FitOptions) FitResult {
+	var r FitResult
+	k := len(c)
+	degree := dataset.Degree()
+
+	var upper, lower []float64
+	var assigned []int
+	var between [][]float64
+	var halfClosest []float64
+	for r.Iterations < opts.MaxIterations {
+		r.Iterations++
+		first := r.Iterations == 1
+		if !first {
+			between, halfClosest = c.centerDistances()
+		}
+		c.clearObservations()
+
+		i := 0
+		for o := range dataset.Observations() {
+			if first {
+				a := 0
+				for j := range c {
+					d := euclideanDistance(o, c[j].Center, degree)
+					lower = append(lower, d)
+					if d < lower[i*k+a] {
+						a = j
+					}
+				}
+				assigned = append(assigned, a)
+				upper = append(upper, lower[i*k+a])
+			} else if a := assigned[i]; upper[i] >= halfClosest[a] {
+				bounds := lower[i*k : (i+1)*k]
+				stale := true
+				for j := range c {
+					if j == a || cannotBeNearer(upper[i], bounds[j], j, a) || cannotBeNearer(upper[i], between[a][j]/2, j, a) {
+						continue
+					}
+					if stale {
+						upper[i] = euclideanDistance(o, c[a].Center, degree)
+						bounds[a] = upper[i]
+						stale = false
+						if cannotBeNearer(upper[i], bounds[j], j, a) || cannotBeNearer(upper[i], between[a][j]/2, j, a) {
+							continue
+						}
+					}
+					d := euclideanDistance(o, c[j].Center, degree)
+					bounds[j] = d
+					if d < upper[i] || (d == upper[i] && j < a) {
+						a = j
+						upper[i] = d
+					}
+				}
+				assigned[i] = a
+			}
+			c[assigned[i]].assign(o)
+			i++
+		}
+
+		previous := c.centers()
+		if c.recenter() <= opts.Tolerance {
+			r.Converged = true
+			break
+		}
+		shifts := c.centerShifts(previous)
+		for i := range assigned {
+			upper[i] += shifts[assigned[i]]
+			for j, s := range shifts {
+				lower[i*k+j] = max(0, lower[i*k+j]-s)
+			}
+		}
+	}
+	return r
+}
+
+// cannotBeNearer reports whether center j, which is at least bound from an observation, cannot
+// replace center a, which is at most upper from it. Ties go to the lower index like Nearest.
+func cannotBeNearer(upper, bound float64, j, a int) bool {
+	return upper < bound || (upper == bound && j > a)
+}
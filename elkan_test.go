@@ -0,0 +1,62 @@
+package kmeans
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFitElkanMatchesLloyd(t *testing.T) {
+	oo := NormalizeObservations(listOfPeople())
+	for k := 2; k <= 7; k++ {
+		lloyd, err := NewWithInitializer(k, oo, KMeansPlusPlus[float64])
+		assert.NoError(t, err)
+		elkan := newClusters(oo.Degree(), centersOf(lloyd))
+
+		lr, err := lloyd.Fit(oo, DefaultFitOptions)
+		assert.NoError(t, err)
+		er, err := elkan.Fit(oo, FitOptions{Algorithm: Elkan})
+		assert.NoError(t, err)
+
+		assert.Equal(t, lr, er)
+		assertSameClusters(t, lloyd, elkan)
+	}
+}
+
+func TestFitElkanMatchesLloydWithTies(t *testing.T) {
+	for seed := range int64(200) {
+		oo := gridPoints(seed, 60, 8)
+		for k := 2; k <= 5; k++ {
+			lloyd := newClusters(oo.Degree(), oo.ClusterObservations[:k])
+			elkan := newClusters(oo.Degree(), oo.ClusterObservations[:k])
+
+			lr, err := lloyd.Fit(oo, DefaultFitOptions)
+			assert.NoError(t, err)
+			er, err := elkan.Fit(oo, FitOptions{Algorithm: Elkan})
+			assert.NoError(t, err)
+
+			assert.Equal(t, lr, er)
+			assertSameClusters(t, lloyd, elkan)
+		}
+	}
+}
+
+// gridPoints returns n random integer points on a size by size grid, where many points
+// are the same distance from two centers
+func gridPoints(seed int64, n, size int) *ObservationList[int] {
+	r := rand.New(rand.NewSource(seed))
+	oo := NewObservationList[int](2)
+	for range n {
+		oo.Append(observationValues[int]{r.Intn(size), r.Intn(size)})
+	}
+	return oo
+}
+
+func assertSameClusters[T Number](t *testing.T, expected, actual Clusters[T]) {
+	assert.Len(t, actual, len(expected))
+	for i := range expected {
+		assert.Equal(t, expected[i].Observations.ClusterObservations, actual[i].Observations.ClusterObservations)
+		assert.Equal(t, expected[i].Center, actual[i].Center)
+	}
+}
@@ -0,0 +1,156 @@
+package kmeans
+
+import "math"
+
+// Algorithm selects the engine Fit uses to assign observations to clusters
+type Algorithm int
+
+const (
+	// Lloyd computes the distance from every observation to every center on each pass
+	Lloyd Algorithm = iota
+	// Elkan uses the triangle inequality with an upper bound and k lower bounds per
+	// observation to skip most distance computations
+	Elkan
+	// Hamerly uses the triangle inequality with a single upper and lower bound per
+	// observation, which needs far less memory than Elkan
+	Hamerly
+)
+
+// FitOptions controls the iterative refinement performed by Fit
+type FitOptions struct {
+	// Algorithm is the assignment engine, Lloyd by default
+	Algorithm Algorithm
+	// MaxIterations is the maximum number of assignment and recenter passes
+	MaxIterations int
+	// Tolerance is the largest movement of any center, as measured by Distance,
+	// that is still considered converged
+	Tolerance float64
+}
+
+// DefaultFitOptions are reasonable limits for most datasets. A zero MaxIterations
+// is replaced with the default limit.
+var DefaultFitOptions = FitOptions{
+	MaxIterations: 100,
+	Tolerance:     1e-9,
+}
+
+// FitResult reports how the refinement of a set of clusters ended
+type FitResult struct {
+	// Iterations is the number of assignment and recenter passes that were run
+	Iterations int
+	// Converged is true when the centers moved less than the tolerance before
+	// the iteration limit was reached
+	Converged bool
+}
+
+func (o FitOptions) withDefaults() FitOptions {
+	if o.MaxIterations <= 0 {
+		o.MaxIterations = DefaultFitOptions.MaxIterations
+	}
+	return o
+}
+
+// Fit refines the clusters using Lloyd's algorithm. Each pass assigns every observation
+// in the dataset to its Nearest cluster and then recenters the clusters. Fit stops once no
+// center moves more than the tolerance or the iteration limit is reached. The clusters
+// retain the observations assigned during the final pass.
+//
+// The accelerated algorithms produce the same clusters as Lloyd, breaking ties toward the
+// lower index, but keep bounds for each observation by its position in the dataset, so the
+// dataset must yield the same observations in the same order on every pass.
+func (c Clusters[T]) Fit(dataset Observations[T], opts FitOptions) (FitResult, error) {
+	if dataset.Degree() == 0 {
+		return FitResult{}, ErrEmptyObservations
+	}
+	if len(c) == 0 {
+		return FitResult{}, ErrKMustBeGreaterThanZero
+	}
+	opts = opts.withDefaults()
+	switch opts.Algorithm {
+	case Elkan:
+		return c.fitElkan(dataset, opts), nil
+	case Hamerly:
+		return c.fitHamerly(dataset, opts), nil
+	default:
+		return c.fitLloyd(dataset, opts), nil
+	}
+}
+
+func (c Clusters[T]) fitLloyd(dataset Observations[T], opts FitOptions) FitResult {
+	var r FitResult
+
+	for r.Iterations < opts.MaxIterations {
+		r.Iterations++
+		c.clearObservations()
+		for o := range dataset.Observations() {
+			c[c.Nearest(o)].assign(o)
+		}
+		if c.recenter() <= opts.Tolerance {
+			r.Converged = true
+			break
+		}
+	}
+	return r
+}
+
+// clearObservations removes all assigned observations while keeping the centers
+func (c Clusters[T]) clearObservations() {
+	for i := range c {
+		c[i].Observations.clear()
+		c[i].sum = nil
+		c[i].weight = 0
+	}
+}
+
+// recenter moves every non-empty cluster to the center of its observations and
+// returns the largest distance moved by any center
+func (c Clusters[T]) recenter() float64 {
+	var shift float64
+	for i := range c {
+		previous := c[i].Center
+		c[i].Recenter()
+		if previous != nil {
+			shift = max(shift, Distance(previous, c[i].Center, c[i].Observations.d))
+		}
+	}
+	return shift
+}
+
+// centers returns the current center of each cluster
+func (c Clusters[T]) centers() []Observation[T] {
+	centers := make([]Observation[T], len(c))
+	for i := range c {
+		centers[i] = c[i].Center
+	}
+	return centers
+}
+
+// centerDistances returns the euclidean distance between every pair of centers
+// and half the distance from each center to its closest other center
+func (c Clusters[T]) centerDistances() (between [][]float64, halfClosest []float64) {
+	between = make([][]float64, len(c))
+	halfClosest = make([]float64, len(c))
+	for i := range c {
+		between[i] = make([]float64, len(c))
+		halfClosest[i] = math.MaxFloat64
+	}
+	for i := range c {
+		for j := i + 1; j < len(c); j++ {
+			d := euclideanDistance(c[i].Center, c[j].Center, c[i].Observations.d)
+			between[i][j] = d
+			between[j][i] = d
+			halfClosest[i] = min(halfClosest[i], d/2)
+			halfClosest[j] = min(halfClosest[j], d/2)
+		}
+	}
+	return between, halfClosest
+}
+
+// centerShifts returns the euclidean distance each center moved from previous
+func (c Clusters[T]) centerShifts(previous []Observation[T]) []float64 {
+	shifts := make([]float64, len(c))
+	for i := range c {
+		shifts[i] = euclideanDistance(previous[i], c[i].Center, c[i].Observations.d)
+	}
+	return shifts
+}
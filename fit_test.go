@@ -0,0 +1,53 @@
+package kmeans
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFit(t *testing.T) {
+	oo := NewNormalizeObservationAdapter(listOfPeople(), nil)
+	for k := 2; k <= 5; k++ {
+		cc, err := New(k, oo)
+		assert.NoError(t, err)
+		once := newClusters(oo.Degree(), centersOf(cc))
+		_, err = once.Fit(oo, FitOptions{MaxIterations: 1})
+		assert.NoError(t, err)
+
+		r, err := cc.Fit(oo, DefaultFitOptions)
+		assert.NoError(t, err)
+		assert.True(t, r.Converged)
+		assert.LessOrEqual(t, r.Iterations, DefaultFitOptions.MaxIterations)
+		assert.LessOrEqual(t, cc.SumClusterVariance(), once.SumClusterVariance()+1e-9)
+
+		n := 0
+		for _, cl := range cc {
+			n += len(cl.Observations.ClusterObservations)
+		}
+		assert.Equal(t, len(listOfPeople()), n)
+	}
+}
+
+func TestFitIterationLimit(t *testing.T) {
+	oo := NewNormalizeObservationAdapter(listOfPeople(), nil)
+	cc, err := New(4, oo)
+	assert.NoError(t, err)
+	r, err := cc.Fit(oo, FitOptions{MaxIterations: 1, Tolerance: -1})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, r.Iterations)
+	assert.False(t, r.Converged)
+
+	_, err = Clusters[int]{{Observations: NewObservationList[int](0)}}.Fit(noObservations(1), DefaultFitOptions)
+	assert.ErrorIs(t, err, ErrEmptyObservations)
+	_, err = Clusters[float32]{}.Fit(oo, DefaultFitOptions)
+	assert.ErrorIs(t, err, ErrKMustBeGreaterThanZero)
+}
+
+func centersOf[T Number](cc Clusters[T]) []Observation[T] {
+	var centers []Observation[T]
+	for _, c := range cc {
+		centers = append(centers, c.Center)
+	}
+	return centers
+}
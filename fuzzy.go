@@ -0,0 +1,159 @@
+package kmeans
+
+import (
+	"fmt"
+	"math"
+)
+
+var ErrInvalidFuzzifier = fmt.Errorf("fuzzifier must be greater than 1")
+
+// FuzzyOptions controls the clustering performed by FuzzyCMeans
+type FuzzyOptions struct {
+	// Fuzzifier controls how soft the memberships are. Values close to 1 approach
+	// k-means while larger values share observations more evenly between clusters.
+	Fuzzifier float64
+	// MaxIterations is the maximum number of membership and center updates
+	MaxIterations int
+	// Tolerance is the largest change of any membership degree that is still
+	// considered converged
+	Tolerance float64
+}
+
+// DefaultFuzzyOptions are reasonable settings for most datasets. A zero Fuzzifier or
+// MaxIterations is replaced with these defaults.
+var DefaultFuzzyOptions = FuzzyOptions{
+	Fuzzifier:     2,
+	MaxIterations: 300,
+	Tolerance:     1e-5,
+}
+
+// FuzzyClusters are soft clusters where every observation belongs to every cluster
+// with a degree of membership between 0 and 1
+type FuzzyClusters[T Number] struct {
+	// Centers are the membership weighted centers of the clusters
+	Centers []Observation[T]
+	// Memberships holds the membership vector of each observation in dataset order.
+	// The degrees in each vector sum to 1.
+	Memberships [][]float64
+	fuzzifier   float64
+	degree      int
+}
+
+// FuzzyCMeans clusters the dataset into c soft clusters using fuzzy c-means. The centers are
+// seeded with KMeansPlusPlus and then alternately the memberships are computed from the
+// distance to the centers and the centers are moved to the mean of the observations weighted
+// by their membership raised to the fuzzifier.
+func FuzzyCMeans[T Number](c int, dataset Observations[T], opts FuzzyOptions) (*FuzzyClusters[T], FitResult, error) {
+	var r FitResult
+	if dataset.Degree() == 0 {
+		return nil, r, ErrEmptyObservations
+	}
+	if c == 0 {
+		return nil, r, ErrKMustBeGreaterThanZero
+	}
+	if opts.Fuzzifier == 0 {
+		opts.Fuzzifier = DefaultFuzzyOptions.Fuzzifier
+	}
+	if opts.Fuzzifier <= 1 {
+		return nil, r, ErrInvalidFuzzifier
+	}
+	if opts.MaxIterations <= 0 {
+		opts.MaxIterations = DefaultFuzzyOptions.MaxIterations
+	}
+
+	fc := &FuzzyClusters[T]{
+		Centers:   KMeansPlusPlus(dataset, c),
+		fuzzifier: opts.Fuzzifier,
+		degree:    dataset.Degree(),
+	}
+	if len(fc.Centers) < c {
+		return nil, r, ErrTooFewObservations
+	}
+
+	for r.Iterations < opts.MaxIterations {
+		r.Iterations++
+		sums := make([][]float64, c)
+		for j := range sums {
+			sums[j] = make([]float64, fc.degree)
+		}
+		weights := make([]float64, c)
+		var change float64
+
+		i := 0
+		for o := range dataset.Observations() {
+			u := fc.MembershipOf(o)
+			if i < len(fc.Memberships) {
+				for j := range u {
+					change = max(change, math.Abs(u[j]-fc.Memberships[i][j]))
+				}
+				fc.Memberships[i] = u
+			} else {
+				fc.Memberships = append(fc.Memberships, u)
+				change = math.MaxFloat64
+			}
+			for j := range u {
+				w := math.Pow(u[j], fc.fuzzifier)
+				weights[j] += w
+				for d := range fc.degree {
+					sums[j][d] += w * float64(o.Values(d))
+				}
+			}
+			i++
+		}
+
+		for j := range fc.Centers {
+			center := make([]T, fc.degree)
+			for d := range fc.degree {
+				center[d] = T(sums[j][d] / weights[j])
+			}
+			fc.Centers[j] = centerObservation[T](center)
+		}
+		if change <= opts.Tolerance {
+			r.Converged = true
+			break
+		}
+	}
+	return fc, r, nil
+}
+
+// Membership returns the membership vector of the i'th observation of the dataset
+func (fc *FuzzyClusters[T]) Membership(i int) []float64 {
+	return fc.Memberships[i]
+}
+
+// MembershipOf returns the membership vector of any observation based on its distance
+// to the cluster centers
+func (fc *FuzzyClusters[T]) MembershipOf(o Observation[T]) []float64 {
+	u := make([]float64, len(fc.Centers))
+	distances := make([]float64, len(fc.Centers))
+	for j, center := range fc.Centers {
+		distances[j] = Distance(o, center, fc.degree)
+		if distances[j] == 0 {
+			// The observation is at a center and belongs only to that cluster
+			u[j] = 1
+			return u
+		}
+	}
+	// Distance is squared so the exponent 2/(m-1) becomes 1/(m-1)
+	exponent := 1 / (fc.fuzzifier - 1)
+	for j := range u {
+		var s float64
+		for l := range distances {
+			s += math.Pow(distances[j]/distances[l], exponent)
+		}
+		u[j] = 1 / s
+	}
+	return u
+}
+
+// Nearest returns the index of the cluster in which the observation has the highest membership
+func (fc *FuzzyClusters[T]) Nearest(o Observation[T]) int {
+	u := fc.MembershipOf(o)
+	ci := 0
+	for j := range u {
+		if u[j] > u[ci] {
+			ci = j
+		}
+	}
+	return ci
+}
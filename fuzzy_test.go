@@ -0,0 +1,37 @@
+package kmeans
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFuzzyCMeans(t *testing.T) {
+	oo := NormalizeObservations(listOfPeople())
+	fc, r, err := FuzzyCMeans(3, oo, DefaultFuzzyOptions)
+	assert.NoError(t, err)
+	assert.True(t, r.Converged)
+	assert.Len(t, fc.Centers, 3)
+	assert.Len(t, fc.Memberships, len(listOfPeople()))
+
+	i := 0
+	for o := range oo.Observations() {
+		u := fc.Membership(i)
+		var sum float64
+		for _, v := range u {
+			assert.GreaterOrEqual(t, v, 0.0)
+			sum += v
+		}
+		assert.InDelta(t, 1.0, sum, 1e-9)
+		assert.InDeltaSlice(t, u, fc.MembershipOf(o), 1e-3)
+		i++
+	}
+
+	assert.Equal(t, []float64{0, 1, 0}, fc.MembershipOf(fc.Centers[1]))
+	assert.Equal(t, 2, fc.Nearest(fc.Centers[2]))
+
+	_, _, err = FuzzyCMeans(3, oo, FuzzyOptions{Fuzzifier: 1})
+	assert.ErrorIs(t, err, ErrInvalidFuzzifier)
+	_, _, err = FuzzyCMeans(0, oo, DefaultFuzzyOptions)
+	assert.ErrorIs(t, err, ErrKMustBeGreaterThanZero)
+}
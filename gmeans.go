@@ -0,0 +1,135 @@
+package kmeans
+
+import (
+	"math"
+	"slices"
+)
+
+// GMeansOptions controls the search performed by GMeans
+type GMeansOptions struct {
+	// MinK is the number of clusters the search starts from
+	MinK int
+	// MaxK is the largest number of clusters that may be created
+	MaxK int
+	// CriticalValue is the adjusted Anderson-Darling statistic above which a cluster is
+	// not considered gaussian and is split
+	CriticalValue float64
+	// Trials is the number of 2-means attempts when splitting each cluster
+	Trials int
+	// Fit controls the refinement of the clusters and the splits
+	Fit FitOptions
+}
+
+// DefaultGMeansOptions are reasonable settings for most datasets. The critical value
+// corresponds to a significance level of 0.0001. Zero values are replaced with these defaults.
+var DefaultGMeansOptions = GMeansOptions{
+	MinK:          1,
+	MaxK:          math.MaxInt,
+	CriticalValue: 1.8692,
+	Trials:        3,
+	Fit:           DefaultFitOptions,
+}
+
+// GMeans chooses the number of clusters by testing whether each cluster is gaussian. Every
+// cluster is split in two as it would be by Bisect and its observations are projected onto
+// the line joining the two new centers. When the projection fails the Anderson-Darling
+// normality test the split is kept. The clusters are refined with Fit after every round of
+// splits until every cluster appears gaussian or MaxK is reached.
+func GMeans[T Number](dataset Observations[T], opts GMeansOptions) (Clusters[T], error) {
+	if opts.MinK <= 0 {
+		opts.MinK = DefaultGMeansOptions.MinK
+	}
+	if opts.MaxK <= 0 {
+		opts.MaxK = DefaultGMeansOptions.MaxK
+	}
+	if opts.MaxK < opts.MinK {
+		return nil, ErrInvalidKRange
+	}
+	if opts.CriticalValue <= 0 {
+		opts.CriticalValue = DefaultGMeansOptions.CriticalValue
+	}
+	if opts.Trials <= 0 {
+		opts.Trials = DefaultGMeansOptions.Trials
+	}
+	cc, err := NewWithInitializer(opts.MinK, dataset, KMeansPlusPlus[T])
+	if err != nil {
+		return nil, err
+	}
+	if len(cc) < opts.MinK {
+		return nil, ErrTooFewObservations
+	}
+	if _, err := cc.Fit(dataset, opts.Fit); err != nil {
+		return nil, err
+	}
+
+	return splitRounds(cc, dataset, opts.MaxK, opts.Trials, opts.Fit, func(cl Cluster[T], halves Clusters[T]) bool {
+		return !gaussianAlong(cl, halves[0].Center, halves[1].Center, opts.CriticalValue)
+	})
+}
+
+// gaussianAlong returns true if the observations of the cluster projected onto the line
+// from c1 to c2 pass the Anderson-Darling normality test
+func gaussianAlong[T Number](cl Cluster[T], c1, c2 Observation[T], criticalValue float64) bool {
+	degree := cl.Observations.d
+	v := make([]float64, degree)
+	var length float64
+	for i := range degree {
+		v[i] = float64(c1.Values(i)) - float64(c2.Values(i))
+		length += v[i] * v[i]
+	}
+	if length == 0 {
+		return true
+	}
+	projected := make([]float64, 0, len(cl.Observations.ClusterObservations))
+	for _, o := range cl.Observations.ClusterObservations {
+		var p float64
+		for i := range degree {
+			p += float64(o.Values(i)) * v[i]
+		}
+		projected = append(projected, p/length)
+	}
+	return andersonDarling(projected) <= criticalValue
+}
+
+// andersonDarling returns the Anderson-Darling statistic of the values against a normal
+// distribution with the mean and variance of the values, adjusted for the sample size
+func andersonDarling(values []float64) float64 {
+	n := float64(len(values))
+	if n < 2 {
+		return 0
+	}
+	var mean, variance float64
+	for _, v := range values {
+		mean += v
+	}
+	mean /= n
+	for _, v := range values {
+		variance += (v - mean) * (v - mean)
+	}
+	variance /= n - 1
+	if variance == 0 {
+		return 0
+	}
+	sd := math.Sqrt(variance)
+
+	z := make([]float64, len(values))
+	for i, v := range values {
+		z[i] = (v - mean) / sd
+	}
+	slices.Sort(z)
+
+	const epsilon = 1e-300
+	var s float64
+	for i := range z {
+		lower := max(normalCDF(z[i]), epsilon)
+		upper := max(1-normalCDF(z[len(z)-1-i]), epsilon)
+		s += float64(2*i+1) * (math.Log(lower) + math.Log(upper))
+	}
+	a := -n - s/n
+	return a * (1 + 4/n - 25/(n*n))
+}
+
+// normalCDF returns the cumulative distribution function of the standard normal distribution
+func normalCDF(z float64) float64 {
+	return 0.5 * math.Erfc(-z/math.Sqrt2)
+}
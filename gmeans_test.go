@@ -0,0 +1,34 @@
+package kmeans
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGMeans(t *testing.T) {
+	pp := blobs([][]float64{{0, 0}, {10, 0}, {0, 10}, {10, 10}}, 100, 1)
+	cc, err := GMeans(pp, DefaultGMeansOptions)
+	assert.NoError(t, err)
+	assert.Len(t, cc, 4)
+
+	cc, err = GMeans(pp, GMeansOptions{MaxK: 2})
+	assert.NoError(t, err)
+	assert.Len(t, cc, 2)
+
+	_, err = GMeans(pp, GMeansOptions{MinK: 3, MaxK: 2})
+	assert.ErrorIs(t, err, ErrInvalidKRange)
+	_, err = GMeans(points{{0, 0}, {1, 1}}, GMeansOptions{MinK: 3})
+	assert.ErrorIs(t, err, ErrTooFewObservations)
+}
+
+func TestAndersonDarling(t *testing.T) {
+	gaussian := blobs([][]float64{{0}}, 500, 1)
+	var normal, uniform []float64
+	for i, p := range gaussian {
+		normal = append(normal, p[0])
+		uniform = append(uniform, float64(i))
+	}
+	assert.Less(t, andersonDarling(normal), DefaultGMeansOptions.CriticalValue)
+	assert.Greater(t, andersonDarling(uniform), DefaultGMeansOptions.CriticalValue)
+}
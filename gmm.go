@@ -0,0 +1,376 @@
+package kmeans
+
+import (
+	"fmt"
+	"math"
+)
+
+var ErrSingularCovariance = fmt.Errorf("covariance matrix is not positive definite, increase the regularization")
+
+// CovarianceType selects the shape of the covariance of each mixture component
+type CovarianceType int
+
+const (
+	// DiagonalCovariance models each dimension independently
+	DiagonalCovariance CovarianceType = iota
+	// FullCovariance models the correlation between every pair of dimensions
+	FullCovariance
+)
+
+// GMMOptions controls the expectation maximization performed by FitGMM
+type GMMOptions struct {
+	// Covariance is the shape of the component covariances
+	Covariance CovarianceType
+	// MaxIterations is the maximum number of expectation steps
+	MaxIterations int
+	// Tolerance is the smallest improvement in log-likelihood that continues the iterations
+	Tolerance float64
+	// Regularization is added to the diagonal of each covariance to keep it invertible
+	Regularization float64
+	// Trials is the number of k-means clusterings tried to initialize the components,
+	// the clustering with the lowest variance is used
+	Trials int
+	// Fit controls the k-means clustering used to initialize the components
+	Fit FitOptions
+}
+
+// DefaultGMMOptions are reasonable settings for most datasets. Zero values for
+// MaxIterations, Regularization and Trials are replaced with these defaults.
+var DefaultGMMOptions = GMMOptions{
+	Covariance:     DiagonalCovariance,
+	MaxIterations:  100,
+	Tolerance:      1e-6,
+	Regularization: 1e-6,
+	Trials:         3,
+	Fit:            DefaultFitOptions,
+}
+
+// GaussianMixture is a mixture of gaussian components fitted to a dataset
+type GaussianMixture[T Number] struct {
+	// Means is the mean of each component
+	Means [][]float64
+	// Covariances is the covariance matrix of each component. Only the diagonal is
+	// non-zero for DiagonalCovariance.
+	Covariances [][][]float64
+	// Weights are the mixing weights of the components which sum to 1
+	Weights []float64
+	// LogLikelihood is the log-likelihood of the dataset given the mixture
+	LogLikelihood float64
+	// Posteriors holds the probability of each component for each observation in dataset order
+	Posteriors [][]float64
+
+	covariance CovarianceType
+	n          int
+	degree     int
+	// cholesky holds the lower triangular factor of each covariance
+	cholesky [][][]float64
+}
+
+// FitGMM fits a mixture of k gaussian components to the dataset using expectation
+// maximization. The components are initialized from the best of several k-means clusterings
+// seeded with KMeansPlusPlus. Each iteration streams the dataset twice.
+func FitGMM[T Number](k int, dataset Observations[T], opts GMMOptions) (*GaussianMixture[T], FitResult, error) {
+	var r FitResult
+	if opts.MaxIterations <= 0 {
+		opts.MaxIterations = DefaultGMMOptions.MaxIterations
+	}
+	if opts.Regularization <= 0 {
+		opts.Regularization = DefaultGMMOptions.Regularization
+	}
+	if opts.Trials <= 0 {
+		opts.Trials = DefaultGMMOptions.Trials
+	}
+	var cc Clusters[T]
+	variance := math.MaxFloat64
+	for range opts.Trials {
+		trial, err := NewWithInitializer(k, dataset, KMeansPlusPlus[T])
+		if err != nil {
+			return nil, r, err
+		}
+		if len(trial) < k {
+			return nil, r, ErrTooFewObservations
+		}
+		if _, err := trial.Fit(dataset, opts.Fit); err != nil {
+			return nil, r, err
+		}
+		if v := trial.SumClusterVariance(); v < variance {
+			variance = v
+			cc = trial
+		}
+	}
+
+	g := &GaussianMixture[T]{
+		Means:       make([][]float64, k),
+		Covariances: make([][][]float64, k),
+		Weights:     make([]float64, k),
+		covariance:  opts.Covariance,
+		degree:      dataset.Degree(),
+	}
+	for j, cl := range cc {
+		responsibilities := make([]float64, len(cl.Observations.ClusterObservations))
+		for i := range responsibilities {
+			responsibilities[i] = 1
+		}
+		g.n += len(responsibilities)
+		g.Weights[j] = float64(len(responsibilities))
+		g.Means[j] = weightedMean(cl.Observations.ClusterObservations, responsibilities, g.degree)
+		g.Covariances[j] = g.weightedCovariance(cl.Observations.ClusterObservations, responsibilities, g.Means[j], opts.Regularization)
+	}
+	for j := range g.Weights {
+		g.Weights[j] /= float64(g.n)
+	}
+	if err := g.factor(); err != nil {
+		return nil, r, err
+	}
+
+	previous := math.Inf(-1)
+	for {
+		r.Iterations++
+		g.expectation(dataset)
+		if g.LogLikelihood-previous <= opts.Tolerance {
+			r.Converged = true
+			break
+		}
+		if r.Iterations >= opts.MaxIterations {
+			break
+		}
+		previous = g.LogLikelihood
+		if err := g.maximization(dataset, opts.Regularization); err != nil {
+			return nil, r, err
+		}
+	}
+	return g, r, nil
+}
+
+// expectation computes the posterior of every observation and the log-likelihood of the dataset
+func (g *GaussianMixture[T]) expectation(dataset Observations[T]) {
+	g.Posteriors = g.Posteriors[:0]
+	g.LogLikelihood = 0
+	for o := range dataset.Observations() {
+		p, ll := g.posterior(o)
+		g.Posteriors = append(g.Posteriors, p)
+		g.LogLikelihood += ll
+	}
+	g.n = len(g.Posteriors)
+}
+
+// maximization moves the components to the maximum likelihood parameters given the posteriors
+func (g *GaussianMixture[T]) maximization(dataset Observations[T], regularization float64) error {
+	k := len(g.Weights)
+	totals := make([]float64, k)
+	for j := range k {
+		g.Means[j] = make([]float64, g.degree)
+	}
+	i := 0
+	for o := range dataset.Observations() {
+		for j, p := range g.Posteriors[i] {
+			totals[j] += p
+			for d := range g.degree {
+				g.Means[j][d] += p * float64(o.Values(d))
+			}
+		}
+		i++
+	}
+	for j := range k {
+		g.Weights[j] = totals[j] / float64(g.n)
+		for d := range g.degree {
+			g.Means[j][d] /= max(totals[j], math.SmallestNonzeroFloat64)
+		}
+		g.Covariances[j] = newMatrix(g.degree)
+	}
+
+	i = 0
+	for o := range dataset.Observations() {
+		for j, p := range g.Posteriors[i] {
+			g.addOuterProduct(g.Covariances[j], o, g.Means[j], p)
+		}
+		i++
+	}
+	for j := range k {
+		for a := range g.degree {
+			for b := range g.degree {
+				g.Covariances[j][a][b] /= max(totals[j], math.SmallestNonzeroFloat64)
+			}
+			g.Covariances[j][a][a] += regularization
+		}
+	}
+	return g.factor()
+}
+
+// Posterior returns the probability of each component given the observation
+func (g *GaussianMixture[T]) Posterior(o Observation[T]) []float64 {
+	p, _ := g.posterior(o)
+	return p
+}
+
+// Predict returns the index of the most probable component for the observation
+func (g *GaussianMixture[T]) Predict(o Observation[T]) int {
+	p := g.Posterior(o)
+	ci := 0
+	for j := range p {
+		if p[j] > p[ci] {
+			ci = j
+		}
+	}
+	return ci
+}
+
+// LogLikelihoodOf returns the log of the mixture density at the observation
+func (g *GaussianMixture[T]) LogLikelihoodOf(o Observation[T]) float64 {
+	_, ll := g.posterior(o)
+	return ll
+}
+
+// Parameters returns the number of free parameters in the mixture
+func (g *GaussianMixture[T]) Parameters() int {
+	k := len(g.Weights)
+	covariance := g.degree
+	if g.covariance == FullCovariance {
+		covariance = g.degree * (g.degree + 1) / 2
+	}
+	return k - 1 + k*g.degree + k*covariance
+}
+
+// BIC returns the Bayesian Information Criterion of the mixture, lower values are better
+func (g *GaussianMixture[T]) BIC() float64 {
+	return -2*g.LogLikelihood + float64(g.Parameters())*math.Log(float64(g.n))
+}
+
+// AIC returns the Akaike Information Criterion of the mixture, lower values are better
+func (g *GaussianMixture[T]) AIC() float64 {
+	return -2*g.LogLikelihood + 2*float64(g.Parameters())
+}
+
+// posterior returns the probability of each component given the observation along
+// with the log of the mixture density at the observation
+func (g *GaussianMixture[T]) posterior(o Observation[T]) ([]float64, float64) {
+	logs := make([]float64, len(g.Weights))
+	maxLog := math.Inf(-1)
+	for j := range logs {
+		logs[j] = math.Log(g.Weights[j]) + g.logDensity(o, j)
+		maxLog = max(maxLog, logs[j])
+	}
+	var sum float64
+	for j := range logs {
+		logs[j] = math.Exp(logs[j] - maxLog)
+		sum += logs[j]
+	}
+	for j := range logs {
+		logs[j] /= sum
+	}
+	return logs, maxLog + math.Log(sum)
+}
+
+// logDensity returns the log of the density of component j at the observation
+func (g *GaussianMixture[T]) logDensity(o Observation[T], j int) float64 {
+	l := g.cholesky[j]
+	// Solve L y = x - mean by forward substitution
+	y := make([]float64, g.degree)
+	var mahalanobis, logDet float64
+	for a := range g.degree {
+		v := float64(o.Values(a)) - g.Means[j][a]
+		for b := range a {
+			v -= l[a][b] * y[b]
+		}
+		y[a] = v / l[a][a]
+		mahalanobis += y[a] * y[a]
+		logDet += 2 * math.Log(l[a][a])
+	}
+	return -0.5 * (float64(g.degree)*math.Log(2*math.Pi) + logDet + mahalanobis)
+}
+
+// factor computes the cholesky factor of every covariance
+func (g *GaussianMixture[T]) factor() error {
+	g.cholesky = make([][][]float64, len(g.Covariances))
+	for j, c := range g.Covariances {
+		l, err := cholesky(c)
+		if err != nil {
+			return err
+		}
+		g.cholesky[j] = l
+	}
+	return nil
+}
+
+// weightedCovariance returns the covariance of the observations weighted by their
+// responsibilities with the regularization added to the diagonal
+func (g *GaussianMixture[T]) weightedCovariance(oo []Observation[T], responsibilities []float64, mean []float64, regularization float64) [][]float64 {
+	c := newMatrix(g.degree)
+	var total float64
+	for i, o := range oo {
+		g.addOuterProduct(c, o, mean, responsibilities[i])
+		total += responsibilities[i]
+	}
+	for a := range g.degree {
+		for b := range g.degree {
+			c[a][b] /= max(total, math.SmallestNonzeroFloat64)
+		}
+		c[a][a] += regularization
+	}
+	return c
+}
+
+// addOuterProduct adds the weighted outer product of o - mean to c. Only the diagonal
+// is updated for DiagonalCovariance.
+func (g *GaussianMixture[T]) addOuterProduct(c [][]float64, o Observation[T], mean []float64, weight float64) {
+	for a := range g.degree {
+		da := float64(o.Values(a)) - mean[a]
+		if g.covariance != FullCovariance {
+			c[a][a] += weight * da * da
+			continue
+		}
+		for b := range a + 1 {
+			v := weight * da * (float64(o.Values(b)) - mean[b])
+			c[a][b] += v
+			if a != b {
+				c[b][a] += v
+			}
+		}
+	}
+}
+
+// weightedMean returns the mean of the observations weighted by their responsibilities
+func weightedMean[T Number](oo []Observation[T], responsibilities []float64, degree int) []float64 {
+	mean := make([]float64, degree)
+	var total float64
+	for i, o := range oo {
+		for d := range degree {
+			mean[d] += responsibilities[i] * float64(o.Values(d))
+		}
+		total += responsibilities[i]
+	}
+	for d := range degree {
+		mean[d] /= max(total, math.SmallestNonzeroFloat64)
+	}
+	return mean
+}
+
+func newMatrix(degree int) [][]float64 {
+	m := make([][]float64, degree)
+	for i := range m {
+		m[i] = make([]float64, degree)
+	}
+	return m
+}
+
+// cholesky returns the lower triangular L where L Lᵀ = m
+func cholesky(m [][]float64) ([][]float64, error) {
+	l := newMatrix(len(m))
+	for i := range m {
+		for j := range i + 1 {
+			s := m[i][j]
+			for k := range j {
+				s -= l[i][k] * l[j][k]
+			}
+			if i == j {
+				if s <= 0 {
+					return nil, ErrSingularCovariance
+				}
+				l[i][i] = math.Sqrt(s)
+			} else {
+				l[i][j] = s / l[j][j]
+			}
+		}
+	}
+	return l, nil
+}
@@ -0,0 +1,50 @@
+package kmeans
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFitGMM(t *testing.T) {
+	pp := blobs([][]float64{{0, 0}, {10, 0}, {0, 10}}, 50, 1)
+	for _, covariance := range []CovarianceType{DiagonalCovariance, FullCovariance} {
+		g, r, err := FitGMM(3, pp, GMMOptions{Covariance: covariance})
+		assert.NoError(t, err)
+		assert.True(t, r.Converged)
+		assert.Len(t, g.Posteriors, len(pp))
+		assert.False(t, math.IsNaN(g.LogLikelihood))
+
+		var weights float64
+		for _, w := range g.Weights {
+			weights += w
+		}
+		assert.InDelta(t, 1.0, weights, 1e-9)
+
+		// Observations from the same blob share a component
+		for b := range 3 {
+			j := g.Predict(observationValues[float64](pp[b*50]))
+			for i := b * 50; i < (b+1)*50; i++ {
+				assert.Equal(t, j, g.Predict(observationValues[float64](pp[i])))
+				assert.Greater(t, g.Posteriors[i][j], 0.99)
+			}
+		}
+
+		// A single component explains the data worse
+		one, _, err := FitGMM(1, pp, GMMOptions{Covariance: covariance})
+		assert.NoError(t, err)
+		assert.Less(t, g.BIC(), one.BIC())
+		assert.Less(t, g.AIC(), one.AIC())
+	}
+
+	people := NormalizeObservations(listOfPeople())
+	g, _, err := FitGMM(2, people, GMMOptions{Covariance: FullCovariance, Regularization: 1e-3})
+	assert.NoError(t, err)
+	for o := range people.Observations() {
+		assert.False(t, math.IsNaN(g.LogLikelihoodOf(o)))
+	}
+
+	_, _, err = FitGMM(0, pp, DefaultGMMOptions)
+	assert.ErrorIs(t, err, ErrKMustBeGreaterThanZero)
+}
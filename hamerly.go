@@ -0,0 +1,80 @@
+package kmeans
+
+// fitHamerly refines the clusters with Hamerly's algorithm. Every observation keeps an upper
+// bound on the distance to its assigned center and a single lower bound on the distance to
+// the second closest center. Distances are only computed when the upper bound exceeds both
+// the lower bound and half the distance from the assigned center to its closest other center.
+func (c Clusters[T]) fitHamerly(dataset Observations[T], opts FitOptions) FitResult {
+	var r FitResult
+	degree := dataset.Degree()
+
+	var upper, lower []float64
+	var assigned []int
+	var halfClosest []float64
+	for r.Iterations < opts.MaxIterations {
+		r.Iterations++
+		first := r.Iterations == 1
+		if !first {
+			_, halfClosest = c.centerDistances()
+		}
+		c.clearObservations()
+
+		i := 0
+		for o := range dataset.Observations() {
+			if first {
+				a, u, l := c.nearestTwo(o, degree)
+				assigned = append(assigned, a)
+				upper = append(upper, u)
+				lower = append(lower, l)
+			} else if bound := max(halfClosest[assigned[i]], lower[i]); upper[i] >= bound {
+				// The bounds do not say which center is second closest, so an observation
+				// that may tie with another center is reassigned to break the tie like Nearest
+				upper[i] = euclideanDistance(o, c[assigned[i]].Center, degree)
+				if upper[i] >= bound {
+					assigned[i], upper[i], lower[i] = c.nearestTwo(o, degree)
+				}
+			}
+			c[assigned[i]].assign(o)
+			i++
+		}
+
+		previous := c.centers()
+		if c.recenter() <= opts.Tolerance {
+			r.Converged = true
+			break
+		}
+		shifts := c.centerShifts(previous)
+		for i, a := range assigned {
+			upper[i] += shifts[a]
+			var furthest float64
+			for j, s := range shifts {
+				if j != a {
+					furthest = max(furthest, s)
+				}
+			}
+			lower[i] = max(0, lower[i]-furthest)
+		}
+	}
+	return r
+}
+
+// nearestTwo returns the index of the nearest center along with the euclidean distance
+// to the nearest and second nearest centers
+func (c Clusters[T]) nearestTwo(o Observation[T], degree int) (int, float64, float64) {
+	a := 0
+	first, second := -1.0, -1.0
+	for j := range c {
+		d := euclideanDistance(o, c[j].Center, degree)
+		if first < 0 || d < first {
+			second = first
+			first = d
+			a = j
+		} else if second < 0 || d < second {
+			second = d
+		}
+	}
+	if second < 0 {
+		second = first
+	}
+	return a, first, second
+}
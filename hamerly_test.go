@@ -0,0 +1,42 @@
+package kmeans
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFitHamerlyMatchesLloyd(t *testing.T) {
+	oo := NormalizeObservations(listOfPeople())
+	for k := 1; k <= 7; k++ {
+		lloyd, err := NewWithInitializer(k, oo, KMeansPlusPlus[float64])
+		assert.NoError(t, err)
+		hamerly := newClusters(oo.Degree(), centersOf(lloyd))
+
+		lr, err := lloyd.Fit(oo, DefaultFitOptions)
+		assert.NoError(t, err)
+		hr, err := hamerly.Fit(oo, FitOptions{Algorithm: Hamerly})
+		assert.NoError(t, err)
+
+		assert.Equal(t, lr, hr)
+		assertSameClusters(t, lloyd, hamerly)
+	}
+}
+
+func TestFitHamerlyMatchesLloydWithTies(t *testing.T) {
+	for seed := range int64(200) {
+		oo := gridPoints(seed, 60, 8)
+		for k := 2; k <= 5; k++ {
+			lloyd := newClusters(oo.Degree(), oo.ClusterObservations[:k])
+			hamerly := newClusters(oo.Degree(), oo.ClusterObservations[:k])
+
+			lr, err := lloyd.Fit(oo, DefaultFitOptions)
+			assert.NoError(t, err)
+			hr, err := hamerly.Fit(oo, FitOptions{Algorithm: Hamerly})
+			assert.NoError(t, err)
+
+			assert.Equal(t, lr, hr)
+			assertSameClusters(t, lloyd, hamerly)
+		}
+	}
+}
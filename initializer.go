@@ -0,0 +1,170 @@
+package kmeans
+
+import (
+	"iter"
+	"math/rand"
+)
+
+// An Initializer selects k observations from a dataset to seed the cluster centers.
+// SelectWeightedObservations is the default Initializer.
+type Initializer[T Number] func(oo Observations[T], k int) []Observation[T]
+
+// KMeansPlusPlus selects k observations using k-means++ seeding. The first center is
+// chosen in proportion to its ObservationWeight and each following center is chosen with
+// probability proportional to its weight times its Distance from the nearest center already
+// selected. This requires k passes over the observations.
+func KMeansPlusPlus[T Number](oo Observations[T], k int) []Observation[T] {
+	degree := oo.Degree()
+	return seedPlusPlus(oo.Observations(), k, ObservationWeight[T], func(o1, o2 Observation[T]) float64 {
+		return Distance(o1, o2, degree)
+	})
+}
+
+// KMeansParallel selects k observations using k-means|| seeding with an oversampling
+// factor of 2 and 5 rounds. See NewKMeansParallel.
+func KMeansParallel[T Number](oo Observations[T], k int) []Observation[T] {
+	return NewKMeansParallel[T](2, 5)(oo, k)
+}
+
+// NewKMeansParallel returns a k-means|| Initializer. Each round samples candidates with
+// probability proportional to their weight times their Distance from the current candidates,
+// oversampling by oversampling*k per round. The candidates are weighted by the total weight of
+// the observations nearest to them and reduced to k centers with k-means++. Observations are streamed
+// and never retained, using two passes per round plus one pass to weight the candidates.
+func NewKMeansParallel[T Number](oversampling float64, rounds int) Initializer[T] {
+	return func(oo Observations[T], k int) []Observation[T] {
+		candidates := selectWeightedObservation(oo)
+		if len(candidates) == 0 {
+			return candidates
+		}
+		degree := oo.Degree()
+		l := oversampling * float64(k)
+
+		for range rounds {
+			var cost float64
+			for o := range oo.Observations() {
+				_, d := nearestObservation(o, candidates, degree)
+				cost += ObservationWeight(o) * d
+			}
+			if cost == 0 {
+				break
+			}
+			selected := candidates
+			for o := range oo.Observations() {
+				_, d := nearestObservation(o, selected, degree)
+				if rand.Float64() < l*ObservationWeight(o)*d/cost {
+					candidates = append(candidates, o)
+				}
+			}
+		}
+
+		if len(candidates) < k {
+			// Too few candidates were sampled to recluster
+			return KMeansPlusPlus(oo, k)
+		}
+
+		weights := make([]float64, len(candidates))
+		for o := range oo.Observations() {
+			i, _ := nearestObservation(o, candidates, degree)
+			weights[i] += ObservationWeight(o)
+		}
+		return weightedKMeansPlusPlus(candidates, weights, k, degree)
+	}
+}
+
+// selectWeightedObservation returns one observation chosen in proportion to its ObservationWeight
+func selectWeightedObservation[T Number](oo Observations[T]) []Observation[T] {
+	var selected []Observation[T]
+	total := 0.0
+	for o := range oo.Observations() {
+		w := ObservationWeight(o)
+		total += w
+		if w > 0 && rand.Float64()*total < w {
+			selected = []Observation[T]{o}
+		}
+	}
+	return selected
+}
+
+// nearestObservation returns the index and Distance of the observation in oo nearest to o
+func nearestObservation[T Number](o Observation[T], oo []Observation[T], degree int) (int, float64) {
+	ni := 0
+	dist := -1.0
+	for i, c := range oo {
+		d := Distance(o, c, degree)
+		if dist < 0 || d < dist {
+			dist = d
+			ni = i
+		}
+	}
+	return ni, dist
+}
+
+// weightedKMeansPlusPlus selects k of the observations using k-means++ where each
+// observation's chance of selection is also scaled by its weight
+func weightedKMeansPlusPlus[T Number](oo []Observation[T], weights []float64, k int, degree int) []Observation[T] {
+	if len(oo) <= k {
+		return oo
+	}
+	selected := seedPlusPlus(indices(len(oo)), k, func(i int) float64 {
+		return weights[i]
+	}, func(i, j int) float64 {
+		return Distance(oo[i], oo[j], degree)
+	})
+	centers := make([]Observation[T], len(selected))
+	for c, i := range selected {
+		centers[c] = oo[i]
+	}
+	return centers
+}
+
+// seedPlusPlus selects up to k items with k-means++ seeding. The first item is chosen in
+// proportion to its weight and each following item in proportion to its weight times its
+// distance from the nearest item already selected. Each selection is one pass over items,
+// which must yield the same items in the same order on every pass. Selection stops early
+// once every item with weight coincides with a selected item.
+func seedPlusPlus[E any](items iter.Seq[E], k int, weight func(E) float64, distance func(E, E) float64) []E {
+	var selected []E
+	var nearest []float64
+	for len(selected) < k {
+		var choice E
+		found := false
+		total := 0.0
+		i := 0
+		for e := range items {
+			w := weight(e)
+			if len(selected) > 0 {
+				d := distance(e, selected[len(selected)-1])
+				if i == len(nearest) {
+					nearest = append(nearest, d)
+				} else {
+					nearest[i] = min(nearest[i], d)
+				}
+				w *= nearest[i]
+			}
+			// Weighted reservoir sampling of a single item
+			total += w
+			if w > 0 && rand.Float64()*total < w {
+				choice = e
+				found = true
+			}
+			i++
+		}
+		if !found {
+			break
+		}
+		selected = append(selected, choice)
+	}
+	return selected
+}
+
+// indices yields the integers from 0 to n-1
+func indices(n int) iter.Seq[int] {
+	return func(yield func(int) bool) {
+		for i := range n {
+			if !yield(i) {
+				return
+			}
+		}
+	}
+}
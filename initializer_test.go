@@ -0,0 +1,45 @@
+package kmeans
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKMeansPlusPlus(t *testing.T) {
+	oo := NewNormalizeObservationAdapter(listOfPeople(), nil)
+	for k := 1; k <= 7; k++ {
+		centers := KMeansPlusPlus(oo, k)
+		assert.Len(t, centers, k)
+		for i := range centers {
+			for j := i + 1; j < len(centers); j++ {
+				assert.Greater(t, Distance(centers[i], centers[j], oo.Degree()), 0.0)
+			}
+		}
+	}
+
+	cc, err := NewWithInitializer(3, oo, KMeansPlusPlus[float32])
+	assert.NoError(t, err)
+	assert.Len(t, cc, 3)
+	_, err = NewWithInitializer(0, oo, KMeansPlusPlus[float32])
+	assert.ErrorIs(t, err, ErrKMustBeGreaterThanZero)
+
+	cc, err = OptimizeClustersWithInitializer(3, oo, KMeansPlusPlus[float32])
+	assert.NoError(t, err)
+	assert.Len(t, cc, 3)
+}
+
+func TestKMeansParallel(t *testing.T) {
+	oo := NewNormalizeObservationAdapter(listOfPeople(), nil)
+	for k := 1; k <= 7; k++ {
+		centers := KMeansParallel(oo, k)
+		assert.Len(t, centers, k)
+	}
+
+	cc, err := NewWithInitializer(4, oo, NewKMeansParallel[float32](1, 2))
+	assert.NoError(t, err)
+	assert.Len(t, cc, 4)
+	r, err := cc.Fit(oo, DefaultFitOptions)
+	assert.NoError(t, err)
+	assert.True(t, r.Converged)
+}
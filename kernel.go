@@ -0,0 +1,247 @@
+package kmeans
+
+import "math"
+
+// A Kernel computes the inner product of two observations in an implicit feature space
+type Kernel[T Number] func(o1, o2 Observation[T], degree int) float64
+
+// RBFKernel returns a gaussian radial basis function kernel, exp(-gamma * Distance)
+func RBFKernel[T Number](gamma float64) Kernel[T] {
+	return func(o1, o2 Observation[T], degree int) float64 {
+		return math.Exp(-gamma * Distance(o1, o2, degree))
+	}
+}
+
+// PolynomialKernel returns a polynomial kernel, (o1 · o2 + offset) ^ power
+func PolynomialKernel[T Number](offset float64, power int) Kernel[T] {
+	return func(o1, o2 Observation[T], degree int) float64 {
+		var dot float64
+		for i := range degree {
+			dot += float64(o1.Values(i)) * float64(o2.Values(i))
+		}
+		return math.Pow(dot+offset, float64(power))
+	}
+}
+
+// KernelKMeansOptions controls the clustering performed by KernelKMeans
+type KernelKMeansOptions[T Number] struct {
+	// Kernel defines the feature space the observations are clustered in, RBFKernel(1) when nil
+	Kernel Kernel[T]
+	// MaxIterations is the maximum number of assignment passes
+	MaxIterations int
+	// Trials is the number of differently seeded clusterings tried.
+	// The clustering with the lowest inertia is kept.
+	Trials int
+}
+
+// DefaultKernelKMeansOptions returns reasonable settings for most datasets. A nil Kernel
+// and zero MaxIterations and Trials are replaced with these defaults.
+func DefaultKernelKMeansOptions[T Number]() KernelKMeansOptions[T] {
+	return KernelKMeansOptions[T]{
+		Kernel:        RBFKernel[T](1),
+		MaxIterations: 100,
+		Trials:        5,
+	}
+}
+
+// KernelClusters are clusters found in the feature space of a Kernel. Since the cluster
+// centers cannot be represented as observations, clusters are described by their members.
+type KernelClusters[T Number] struct {
+	// Assignments holds the cluster of each observation in dataset order
+	Assignments []int
+	// Members holds the observations of each cluster
+	Members [][]Observation[T]
+	// Inertia is the sum of the squared feature space distances of the observations
+	// from the mean of their cluster
+	Inertia float64
+	kernel  Kernel[T]
+	degree  int
+	// compactness is the mean kernel value between all pairs of members of each cluster
+	compactness []float64
+}
+
+// KernelKMeans clusters the dataset into k clusters in the feature space of the kernel, which
+// allows groups that are not linearly separable, such as rings, to be clustered. The kernel
+// matrix of every pair of observations is computed up front.
+func KernelKMeans[T Number](k int, dataset Observations[T], opts KernelKMeansOptions[T]) (*KernelClusters[T], FitResult, error) {
+	var r FitResult
+	if dataset.Degree() == 0 {
+		return nil, r, ErrEmptyObservations
+	}
+	if k == 0 {
+		return nil, r, ErrKMustBeGreaterThanZero
+	}
+	defaults := DefaultKernelKMeansOptions[T]()
+	if opts.Kernel == nil {
+		opts.Kernel = defaults.Kernel
+	}
+	if opts.MaxIterations <= 0 {
+		opts.MaxIterations = defaults.MaxIterations
+	}
+	if opts.Trials <= 0 {
+		opts.Trials = defaults.Trials
+	}
+	var oo []Observation[T]
+	for o := range dataset.Observations() {
+		oo = append(oo, o)
+	}
+	if len(oo) < k {
+		return nil, r, ErrTooFewObservations
+	}
+
+	degree := dataset.Degree()
+	gram := make([][]float64, len(oo))
+	for i := range oo {
+		gram[i] = make([]float64, len(oo))
+		for j := range i + 1 {
+			v := opts.Kernel(oo[i], oo[j], degree)
+			gram[i][j] = v
+			gram[j][i] = v
+		}
+	}
+
+	var assignments []int
+	inertia := math.MaxFloat64
+	for range opts.Trials {
+		trial, tr, trialInertia := kernelAssign(gram, k, opts.MaxIterations)
+		if trialInertia < inertia {
+			assignments, r, inertia = trial, tr, trialInertia
+		}
+	}
+
+	kc := &KernelClusters[T]{
+		Assignments: assignments,
+		Members:     make([][]Observation[T], k),
+		Inertia:     inertia,
+		kernel:      opts.Kernel,
+		degree:      degree,
+		compactness: make([]float64, k),
+	}
+	for i, a := range assignments {
+		kc.Members[a] = append(kc.Members[a], oo[i])
+	}
+	for i, a := range assignments {
+		for j, b := range assignments {
+			if a == b {
+				kc.compactness[a] += gram[i][j]
+			}
+		}
+	}
+	for c, m := range kc.Members {
+		if len(m) > 0 {
+			kc.compactness[c] /= float64(len(m) * len(m))
+		}
+	}
+	return kc, r, nil
+}
+
+// kernelAssign clusters the observations described by the gram matrix and returns the
+// cluster of each observation along with the inertia of the clustering
+func kernelAssign(gram [][]float64, k int, maxIterations int) ([]int, FitResult, float64) {
+	var r FitResult
+	n := len(gram)
+	assignments := kernelSeeds(gram, k)
+	sizes := make([]int, k)
+	compactness := make([]float64, k)
+	sums := make([][]float64, n)
+	for i := range sums {
+		sums[i] = make([]float64, k)
+	}
+	var inertia float64
+	for r.Iterations < maxIterations {
+		r.Iterations++
+		// Sum of kernel values between each observation and the members of each cluster
+		for i := range sums {
+			clear(sums[i])
+		}
+		clear(sizes)
+		clear(compactness)
+		for j, a := range assignments {
+			sizes[a]++
+			for i := range n {
+				sums[i][a] += gram[i][j]
+			}
+		}
+		for i, a := range assignments {
+			compactness[a] += sums[i][a]
+		}
+		for c := range k {
+			if sizes[c] > 0 {
+				compactness[c] /= float64(sizes[c] * sizes[c])
+			}
+		}
+
+		changed := false
+		inertia = 0
+		for i := range n {
+			best := assignments[i]
+			dist := math.MaxFloat64
+			for c := range k {
+				if sizes[c] == 0 {
+					continue
+				}
+				d := gram[i][i] - 2*sums[i][c]/float64(sizes[c]) + compactness[c]
+				if d < dist {
+					dist = d
+					best = c
+				}
+			}
+			inertia += dist
+			if best != assignments[i] {
+				assignments[i] = best
+				changed = true
+			}
+		}
+		if !changed {
+			r.Converged = true
+			break
+		}
+	}
+	return assignments, r, inertia
+}
+
+// KernelDistance returns the squared distance in feature space between the observation
+// and the mean of the members of cluster c
+func (kc *KernelClusters[T]) KernelDistance(o Observation[T], c int) float64 {
+	var sum float64
+	for _, m := range kc.Members[c] {
+		sum += kc.kernel(o, m, kc.degree)
+	}
+	return kc.kernel(o, o, kc.degree) - 2*sum/float64(len(kc.Members[c])) + kc.compactness[c]
+}
+
+// Predict returns the index of the cluster nearest to the observation in feature space
+func (kc *KernelClusters[T]) Predict(o Observation[T]) int {
+	ci := 0
+	dist := math.MaxFloat64
+	for c := range kc.Members {
+		if len(kc.Members[c]) == 0 {
+			continue
+		}
+		if d := kc.KernelDistance(o, c); d < dist {
+			dist = d
+			ci = c
+		}
+	}
+	return ci
+}
+
+// kernelSeeds selects k seeds with k-means++ using the kernel distance and returns the
+// index of the nearest seed for each observation
+func kernelSeeds(gram [][]float64, k int) []int {
+	distance := func(i, j int) float64 {
+		return gram[i][i] - 2*gram[i][j] + gram[j][j]
+	}
+	seeds := seedPlusPlus(indices(len(gram)), k, func(int) float64 { return 1 }, distance)
+	assignments := make([]int, len(gram))
+	for i := range gram {
+		nearest := math.MaxFloat64
+		for c, seed := range seeds {
+			if d := distance(i, seed); d < nearest {
+				nearest = d
+				assignments[i] = c
+			}
+		}
+	}
+	return assignments
+}
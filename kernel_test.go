@@ -0,0 +1,53 @@
+package kmeans
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// rings generates n points on each of two concentric circles
+func rings(n int) points {
+	var pp points
+	for _, radius := range []float64{1, 5} {
+		for i := range n {
+			angle := 2 * math.Pi * float64(i) / float64(n)
+			pp = append(pp, []float64{radius * math.Cos(angle), radius * math.Sin(angle)})
+		}
+	}
+	return pp
+}
+
+func TestKernelKMeansRings(t *testing.T) {
+	pp := rings(40)
+	kc, r, err := KernelKMeans(2, pp, KernelKMeansOptions[float64]{Kernel: RBFKernel[float64](0.2), Trials: 20})
+	assert.NoError(t, err)
+	assert.True(t, r.Converged)
+	assert.Len(t, kc.Assignments, len(pp))
+
+	// Each ring forms its own cluster
+	for i := range pp {
+		assert.Equal(t, kc.Assignments[i/40*40], kc.Assignments[i])
+	}
+	assert.NotEqual(t, kc.Assignments[0], kc.Assignments[40])
+	assert.Equal(t, kc.Assignments[0], kc.Predict(observationValues[float64]{0.9, 0}))
+	assert.Equal(t, kc.Assignments[40], kc.Predict(observationValues[float64]{0, -5.2}))
+}
+
+func TestKernelKMeans(t *testing.T) {
+	oo := NormalizeObservations(listOfPeople())
+	kc, _, err := KernelKMeans(3, oo, KernelKMeansOptions[float64]{Kernel: PolynomialKernel[float64](1, 2)})
+	assert.NoError(t, err)
+	n := 0
+	for c, members := range kc.Members {
+		n += len(members)
+		for _, m := range members {
+			assert.Equal(t, c, kc.Predict(m))
+		}
+	}
+	assert.Equal(t, len(listOfPeople()), n)
+
+	_, _, err = KernelKMeans(0, oo, DefaultKernelKMeansOptions[float64]())
+	assert.ErrorIs(t, err, ErrKMustBeGreaterThanZero)
+}
@@ -0,0 +1,186 @@
+package kmeans
+
+import (
+	"fmt"
+	"math"
+)
+
+var ErrTooFewObservations = fmt.Errorf("there are fewer observations than clusters")
+
+// KMedoidsOptions controls the swap phase of KMedoids
+type KMedoidsOptions struct {
+	// MaxIterations is the maximum number of swaps performed
+	MaxIterations int
+}
+
+// DefaultKMedoidsOptions are reasonable limits for most datasets. A zero MaxIterations
+// is replaced with the default limit.
+var DefaultKMedoidsOptions = KMedoidsOptions{
+	MaxIterations: 100,
+}
+
+// KMedoids clusters the dataset around k medoids using Partitioning Around Medoids (PAM).
+// The Center of every returned cluster is one of the observations in the dataset. PAM
+// needs the distance between every pair of observations, so use CLARA for large datasets.
+func KMedoids[T Number](k int, dataset Observations[T], opts KMedoidsOptions) (Clusters[T], FitResult, error) {
+	var r FitResult
+	if dataset.Degree() == 0 {
+		return nil, r, ErrEmptyObservations
+	}
+	if k == 0 {
+		return nil, r, ErrKMustBeGreaterThanZero
+	}
+	if opts.MaxIterations <= 0 {
+		opts.MaxIterations = DefaultKMedoidsOptions.MaxIterations
+	}
+	var oo []Observation[T]
+	for o := range dataset.Observations() {
+		oo = append(oo, o)
+	}
+	if len(oo) < k {
+		return nil, r, ErrTooFewObservations
+	}
+
+	p := newPam(oo, dataset.Degree())
+	p.build(k)
+	for r.Iterations < opts.MaxIterations {
+		if !p.swap() {
+			r.Converged = true
+			break
+		}
+		r.Iterations++
+	}
+	return p.clusters(), r, nil
+}
+
+// pam holds the state of the Partitioning Around Medoids algorithm
+type pam[T Number] struct {
+	oo       []Observation[T]
+	degree   int
+	dist     [][]float64
+	medoids  []int
+	nearest  []float64
+	second   []float64
+	assigned []int
+}
+
+func newPam[T Number](oo []Observation[T], degree int) *pam[T] {
+	p := &pam[T]{
+		oo:     oo,
+		degree: degree,
+		dist:   make([][]float64, len(oo)),
+	}
+	for i := range oo {
+		p.dist[i] = make([]float64, len(oo))
+		for j := range i {
+			d := euclideanDistance(oo[i], oo[j], degree)
+			p.dist[i][j] = d
+			p.dist[j][i] = d
+		}
+	}
+	return p
+}
+
+// build greedily selects k medoids
+func (p *pam[T]) build(k int) {
+	p.nearest = make([]float64, len(p.oo))
+	for i := range p.nearest {
+		p.nearest[i] = math.MaxFloat64
+	}
+	isMedoid := make([]bool, len(p.oo))
+	for range k {
+		best := -1
+		bestCost := math.MaxFloat64
+		for h := range p.oo {
+			if isMedoid[h] {
+				continue
+			}
+			var cost float64
+			for j := range p.oo {
+				cost += min(p.nearest[j], p.dist[j][h])
+			}
+			if cost < bestCost {
+				bestCost = cost
+				best = h
+			}
+		}
+		isMedoid[best] = true
+		p.medoids = append(p.medoids, best)
+		for j := range p.oo {
+			p.nearest[j] = min(p.nearest[j], p.dist[j][best])
+		}
+	}
+	p.update()
+}
+
+// update recomputes the nearest medoid of each observation along with the distances
+// to the nearest and second nearest medoids
+func (p *pam[T]) update() {
+	p.nearest = make([]float64, len(p.oo))
+	p.second = make([]float64, len(p.oo))
+	p.assigned = make([]int, len(p.oo))
+	for j := range p.oo {
+		p.nearest[j] = math.MaxFloat64
+		p.second[j] = math.MaxFloat64
+		for mi, m := range p.medoids {
+			d := p.dist[j][m]
+			if d < p.nearest[j] {
+				p.second[j] = p.nearest[j]
+				p.nearest[j] = d
+				p.assigned[j] = mi
+			} else if d < p.second[j] {
+				p.second[j] = d
+			}
+		}
+	}
+}
+
+// swap performs the medoid and non-medoid exchange that most reduces the total distance.
+// It returns false if no exchange reduces the total distance.
+func (p *pam[T]) swap() bool {
+	isMedoid := make([]bool, len(p.oo))
+	for _, m := range p.medoids {
+		isMedoid[m] = true
+	}
+	bestMedoid, bestCandidate := -1, -1
+	bestDelta := 0.0
+	for mi := range p.medoids {
+		for h := range p.oo {
+			if isMedoid[h] {
+				continue
+			}
+			var delta float64
+			for j := range p.oo {
+				if p.assigned[j] == mi {
+					delta += min(p.second[j], p.dist[j][h]) - p.nearest[j]
+				} else {
+					delta += min(p.nearest[j], p.dist[j][h]) - p.nearest[j]
+				}
+			}
+			if delta < bestDelta-1e-12 {
+				bestDelta = delta
+				bestMedoid = mi
+				bestCandidate = h
+			}
+		}
+	}
+	if bestMedoid < 0 {
+		return false
+	}
+	p.medoids[bestMedoid] = bestCandidate
+	p.update()
+	return true
+}
+
+// clusters returns a cluster around each medoid holding its nearest observations
+func (p *pam[T]) clusters() Clusters[T] {
+	centers := make([]Observation[T], len(p.medoids))
+	for i, m := range p.medoids {
+		centers[i] = p.oo[m]
+	}
+	c := newClusters(p.degree, centers)
+	for j, o := range p.oo {
+		c[p.assigned[j]].assign(o)
+	}
+	return c
+}
@@ -0,0 +1,37 @@
+package kmeans
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKMedoids(t *testing.T) {
+	oo := NormalizeObservations(listOfPeople())
+	previous := -1.0
+	for k := 1; k <= 6; k++ {
+		cc, r, err := KMedoids(k, oo, DefaultKMedoidsOptions)
+		assert.NoError(t, err)
+		assert.True(t, r.Converged)
+		assert.Len(t, cc, k)
+
+		var cost float64
+		for _, cl := range cc {
+			// Every center is a real observation assigned to its own cluster
+			assert.IsType(t, NormalizedObservation[float64, float32]{}, cl.Center)
+			assert.Contains(t, cl.Observations.ClusterObservations, cl.Center)
+			for _, o := range cl.Observations.ClusterObservations {
+				cost += euclideanDistance(o, cl.Center, oo.Degree())
+			}
+		}
+		if previous >= 0 {
+			assert.LessOrEqual(t, cost, previous)
+		}
+		previous = cost
+	}
+
+	_, _, err := KMedoids(len(listOfPeople())+1, oo, DefaultKMedoidsOptions)
+	assert.ErrorIs(t, err, ErrTooFewObservations)
+	_, _, err = KMedoids(0, oo, DefaultKMedoidsOptions)
+	assert.ErrorIs(t, err, ErrKMustBeGreaterThanZero)
+}
@@ -0,0 +1,135 @@
+package kmeans
+
+import "iter"
+
+// CategoricalObservation is an observation whose values are categories, such as codes or
+// labels, that can only be compared for equality
+type CategoricalObservation[C comparable] interface {
+	Category(i int) C
+}
+
+// CategoricalObservations is a collection of CategoricalObservation objects
+type CategoricalObservations[C comparable] interface {
+	Observations() iter.Seq[CategoricalObservation[C]]
+	// Degree is the number of categories in each observation
+	Degree() int
+}
+
+// MatchingDissimilarity returns the number of categories that differ between two observations
+func MatchingDissimilarity[C comparable](o1, o2 CategoricalObservation[C], degree int) int {
+	var d int
+	for i := range degree {
+		if o1.Category(i) != o2.Category(i) {
+			d++
+		}
+	}
+	return d
+}
+
+type modeObservation[C comparable] []C
+
+func (m modeObservation[C]) Category(i int) C {
+	return m[i]
+}
+
+// A ModeCluster is a cluster of categorical observations around the mode of each category
+type ModeCluster[C comparable] struct {
+	Center       CategoricalObservation[C]
+	Observations []CategoricalObservation[C]
+}
+
+// ModeClusters is a set of ModeCluster found by KModes
+type ModeClusters[C comparable] []ModeCluster[C]
+
+// Nearest returns the index of the cluster with the fewest categories differing from the observation
+func (c ModeClusters[C]) Nearest(o CategoricalObservation[C], degree int) int {
+	ci := 0
+	dist := -1
+	for i, cl := range c {
+		if d := MatchingDissimilarity(o, cl.Center, degree); dist < 0 || d < dist {
+			dist = d
+			ci = i
+		}
+	}
+	return ci
+}
+
+// KModes clusters categorical observations into k clusters using MatchingDissimilarity and
+// centers holding the most frequent value of each category. Tolerance is not used.
+func KModes[C comparable](k int, dataset CategoricalObservations[C], opts FitOptions) (ModeClusters[C], FitResult, error) {
+	var r FitResult
+	degree := dataset.Degree()
+	if degree == 0 {
+		return nil, r, ErrEmptyObservations
+	}
+	if k == 0 {
+		return nil, r, ErrKMustBeGreaterThanZero
+	}
+	opts = opts.withDefaults()
+	centers := kModesSeeds(dataset, k)
+	if len(centers) < k {
+		return nil, r, ErrTooFewObservations
+	}
+	c := make(ModeClusters[C], k)
+	for i := range c {
+		c[i].Center = centers[i]
+	}
+
+	for r.Iterations < opts.MaxIterations {
+		r.Iterations++
+		counts := make([][]map[C]int, k)
+		for i := range c {
+			c[i].Observations = nil
+			counts[i] = make([]map[C]int, degree)
+			for d := range degree {
+				counts[i][d] = make(map[C]int)
+			}
+		}
+		for o := range dataset.Observations() {
+			i := c.Nearest(o, degree)
+			c[i].Observations = append(c[i].Observations, o)
+			for d := range degree {
+				counts[i][d][o.Category(d)]++
+			}
+		}
+		changed := false
+		for i := range c {
+			if len(c[i].Observations) == 0 {
+				continue
+			}
+			center := make(modeObservation[C], degree)
+			for d := range degree {
+				center[d] = mode(counts[i][d], c[i].Center.Category(d))
+			}
+			if MatchingDissimilarity[C](center, c[i].Center, degree) > 0 {
+				changed = true
+			}
+			c[i].Center = center
+		}
+		if !changed {
+			r.Converged = true
+			break
+		}
+	}
+	return c, r, nil
+}
+
+// kModesSeeds selects up to k distinct observations using k-means++ seeding with MatchingDissimilarity
+func kModesSeeds[C comparable](dataset CategoricalObservations[C], k int) []CategoricalObservation[C] {
+	degree := dataset.Degree()
+	return seedPlusPlus(dataset.Observations(), k, func(CategoricalObservation[C]) float64 { return 1 },
+		func(o1, o2 CategoricalObservation[C]) float64 {
+			d := float64(MatchingDissimilarity(o1, o2, degree))
+			return d * d
+		})
+}
+
+// mode returns the category with the highest count, keeping current when it ties for the highest
+func mode[C comparable, N int | float64](counts map[C]N, current C) C {
+	for category, n := range counts {
+		if n > counts[current] {
+			current = category
+		}
+	}
+	return current
+}
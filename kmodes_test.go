@@ -0,0 +1,91 @@
+package kmeans
+
+import (
+	"iter"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type categories []string
+
+func (c categories) Category(i int) string {
+	return c[i]
+}
+
+type categoricalRecords []categories
+
+func (r categoricalRecords) Observations() iter.Seq[CategoricalObservation[string]] {
+	return func(yield func(CategoricalObservation[string]) bool) {
+		for _, o := range r {
+			if !yield(o) {
+				return
+			}
+		}
+	}
+}
+
+func (r categoricalRecords) Degree() int {
+	if len(r) == 0 {
+		return 0
+	}
+	return len(r[0])
+}
+
+func TestKModes(t *testing.T) {
+	records := categoricalRecords{
+		{"red", "small", "round", "sweet"},
+		{"red", "small", "round", "sour"},
+		{"red", "small", "oval", "sweet"},
+		{"orange", "small", "round", "sweet"},
+		{"green", "large", "long", "bitter"},
+		{"green", "large", "long", "mild"},
+		{"green", "medium", "long", "bitter"},
+		{"yellow", "large", "long", "bitter"},
+	}
+	assert.Equal(t, 2, MatchingDissimilarity[string](records[0], records[5][:2], 2))
+
+	cc, r := bestOf(t, func() (ModeClusters[string], FitResult, error) {
+		return KModes(2, records, DefaultFitOptions)
+	}, func(cc ModeClusters[string]) float64 {
+		cost := 0
+		for _, cl := range cc {
+			for _, o := range cl.Observations {
+				cost += MatchingDissimilarity(o, cl.Center, records.Degree())
+			}
+		}
+		return float64(cost)
+	})
+	assert.True(t, r.Converged)
+	assert.Len(t, cc, 2)
+
+	red := cc[cc.Nearest(records[0], records.Degree())]
+	assert.Equal(t, categories{"red", "small", "round", "sweet"}, categories(red.Center.(modeObservation[string])))
+	assert.Len(t, red.Observations, 4)
+	green := cc[cc.Nearest(records[4], records.Degree())]
+	assert.Equal(t, categories{"green", "large", "long", "bitter"}, categories(green.Center.(modeObservation[string])))
+	assert.Len(t, green.Observations, 4)
+
+	_, _, err := KModes(len(records)+1, records, DefaultFitOptions)
+	assert.ErrorIs(t, err, ErrTooFewObservations)
+	_, _, err = KModes(0, records, DefaultFitOptions)
+	assert.ErrorIs(t, err, ErrKMustBeGreaterThanZero)
+	_, _, err = KModes(2, categoricalRecords{}, DefaultFitOptions)
+	assert.ErrorIs(t, err, ErrEmptyObservations)
+}
+
+// bestOf keeps the lowest cost of a few randomly seeded clusterings
+func bestOf[C any](t *testing.T, cluster func() (C, FitResult, error), cost func(C) float64) (C, FitResult) {
+	var best C
+	var r FitResult
+	lowest := math.MaxFloat64
+	for range 5 {
+		trial, tr, err := cluster()
+		assert.NoError(t, err)
+		if c := cost(trial); c < lowest {
+			best, r, lowest = trial, tr, c
+		}
+	}
+	return best, r
+}
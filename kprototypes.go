@@ -0,0 +1,210 @@
+package kmeans
+
+import (
+	"fmt"
+	"math"
+)
+
+var ErrInvalidCategorical = fmt.Errorf("categorical dimension is out of range")
+
+// KPrototypesOptions controls the clustering performed by KPrototypes
+type KPrototypesOptions struct {
+	// Categorical lists the dimensions whose values are category codes rather than quantities
+	Categorical []int
+	// Gamma is the cost of each categorical mismatch relative to the squared distance of the
+	// numeric dimensions. When zero it is half the average standard deviation of the numeric
+	// dimensions, or 1 when every dimension is categorical.
+	Gamma float64
+	// Fit limits the number of passes. Tolerance is compared with the Distance each
+	// center moves.
+	Fit FitOptions
+}
+
+// prototypes measures the mixed distance used by KPrototypes
+type prototypes[T Number] struct {
+	categorical []bool
+	gamma       float64
+	degree      int
+}
+
+// PrototypeClusters are the clusters found by KPrototypes along with the mixed distance
+// used to find them. The methods of Clusters treat categories as quantities, so use
+// Nearest and Distance to compare observations with the centers.
+type PrototypeClusters[T Number] struct {
+	Clusters Clusters[T]
+	p        prototypes[T]
+}
+
+// Distance returns the squared euclidean distance between the numeric dimensions of two
+// observations plus Gamma for every categorical dimension whose values differ
+func (pc *PrototypeClusters[T]) Distance(o1, o2 Observation[T]) float64 {
+	return pc.p.distance(o1, o2)
+}
+
+// Nearest returns the index of the cluster nearest to the observation by Distance
+func (pc *PrototypeClusters[T]) Nearest(o Observation[T]) int {
+	return pc.p.nearest(pc.Clusters, o)
+}
+
+// Gamma returns the cost of each categorical mismatch
+func (pc *PrototypeClusters[T]) Gamma() float64 {
+	return pc.p.gamma
+}
+
+func newPrototypes[T Number](degree int, categorical []int, gamma float64) (prototypes[T], error) {
+	p := prototypes[T]{
+		categorical: make([]bool, degree),
+		gamma:       gamma,
+		degree:      degree,
+	}
+	for _, i := range categorical {
+		if i < 0 || i >= degree {
+			return p, fmt.Errorf("%w: %d is not between 0 and %d", ErrInvalidCategorical, i, degree-1)
+		}
+		p.categorical[i] = true
+	}
+	return p, nil
+}
+
+func (p prototypes[T]) distance(o1, o2 Observation[T]) float64 {
+	var d float64
+	for i := range p.degree {
+		if p.categorical[i] {
+			if o1.Values(i) != o2.Values(i) {
+				d += p.gamma
+			}
+			continue
+		}
+		diff := float64(o1.Values(i)) - float64(o2.Values(i))
+		d += diff * diff
+	}
+	return d
+}
+
+// nearest returns the index of the cluster nearest to o
+func (p prototypes[T]) nearest(c Clusters[T], o Observation[T]) int {
+	ci := 0
+	dist := math.MaxFloat64
+	for i := range c {
+		if d := p.distance(o, c[i].Center); d < dist {
+			dist = d
+			ci = i
+		}
+	}
+	return ci
+}
+
+// defaultGamma returns half the average weighted standard deviation of the numeric dimensions
+func (p prototypes[T]) defaultGamma(dataset Observations[T]) float64 {
+	sum := make([]float64, p.degree)
+	squares := make([]float64, p.degree)
+	var weight float64
+	for o := range dataset.Observations() {
+		w := ObservationWeight(o)
+		weight += w
+		for i := range p.degree {
+			v := float64(o.Values(i))
+			sum[i] += w * v
+			squares[i] += w * v * v
+		}
+	}
+	var total float64
+	numeric := 0
+	for i := range p.degree {
+		if p.categorical[i] {
+			continue
+		}
+		numeric++
+		if weight > 0 {
+			mean := sum[i] / weight
+			total += math.Sqrt(max(squares[i]/weight-mean*mean, 0))
+		}
+	}
+	if numeric == 0 || total == 0 {
+		return 1
+	}
+	return total / float64(numeric) / 2
+}
+
+// KPrototypes clusters observations that mix numeric and categorical dimensions into k clusters
+// whose centers hold the mean of the numeric dimensions and the mode of the categorical ones.
+func KPrototypes[T Number](k int, dataset Observations[T], opts KPrototypesOptions) (*PrototypeClusters[T], FitResult, error) {
+	var r FitResult
+	degree := dataset.Degree()
+	if degree == 0 {
+		return nil, r, ErrEmptyObservations
+	}
+	if k == 0 {
+		return nil, r, ErrKMustBeGreaterThanZero
+	}
+	p, err := newPrototypes[T](degree, opts.Categorical, opts.Gamma)
+	if err != nil {
+		return nil, r, err
+	}
+	if p.gamma <= 0 {
+		p.gamma = p.defaultGamma(dataset)
+	}
+	fit := opts.Fit.withDefaults()
+
+	centers := p.seeds(dataset, k)
+	if len(centers) < k {
+		return nil, r, ErrTooFewObservations
+	}
+	c := newClusters(degree, centers)
+	for r.Iterations < fit.MaxIterations {
+		r.Iterations++
+		sums := make([][]float64, k)
+		weights := make([]float64, k)
+		counts := make([][]map[T]float64, k)
+		c.clearObservations()
+		for i := range c {
+			sums[i] = make([]float64, degree)
+			counts[i] = make([]map[T]float64, degree)
+			for d := range degree {
+				if p.categorical[d] {
+					counts[i][d] = make(map[T]float64)
+				}
+			}
+		}
+		for o := range dataset.Observations() {
+			i := p.nearest(c, o)
+			c[i].assign(o)
+			w := ObservationWeight(o)
+			weights[i] += w
+			for d := range degree {
+				if p.categorical[d] {
+					counts[i][d][o.Values(d)] += w
+				} else {
+					sums[i][d] += w * float64(o.Values(d))
+				}
+			}
+		}
+
+		var shift float64
+		for i := range c {
+			if weights[i] == 0 {
+				continue
+			}
+			center := make(centerObservation[T], degree)
+			for d := range degree {
+				if !p.categorical[d] {
+					center[d] = T(sums[i][d] / weights[i])
+					continue
+				}
+				center[d] = mode(counts[i][d], c[i].Center.Values(d))
+			}
+			shift = max(shift, p.distance(c[i].Center, center))
+			c[i].Center = center
+		}
+		if shift <= fit.Tolerance {
+			r.Converged = true
+			break
+		}
+	}
+	return &PrototypeClusters[T]{Clusters: c, p: p}, r, nil
+}
+
+// seeds selects up to k observations using k-means++ seeding with the prototype distance
+func (p prototypes[T]) seeds(dataset Observations[T], k int) []Observation[T] {
+	return seedPlusPlus(dataset.Observations(), k, ObservationWeight[T], p.distance)
+}
@@ -0,0 +1,56 @@
+package kmeans
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKPrototypes(t *testing.T) {
+	// Two numeric blobs with a category column that mostly agrees with the blob
+	pp := blobs([][]float64{{0, 0, 0}, {10, 0, 0}}, 50, 1)
+	for i, p := range pp {
+		p[2] = float64(i / 50)
+		if i%10 == 0 {
+			p[2] = 2
+		}
+	}
+	opts := KPrototypesOptions{Categorical: []int{2}}
+
+	pc, r := bestOf(t, func() (*PrototypeClusters[float64], FitResult, error) {
+		return KPrototypes(2, pp, opts)
+	}, func(pc *PrototypeClusters[float64]) float64 {
+		cost := 0.0
+		for _, cl := range pc.Clusters {
+			for _, o := range cl.Observations.ClusterObservations {
+				cost += pc.Distance(o, cl.Center)
+			}
+		}
+		return cost
+	})
+	assert.True(t, r.Converged)
+	assert.Len(t, pc.Clusters, 2)
+	for category, x := range []float64{0, 10} {
+		cl := pc.Clusters[pc.Nearest(observationValues[float64]{x, 0, float64(category)})]
+		assert.InDelta(t, x, cl.Center.Values(0), 0.5)
+		assert.Equal(t, float64(category), cl.Center.Values(2))
+		assert.Len(t, cl.Observations.ClusterObservations, 50)
+	}
+
+	pc, _, err := KPrototypes(2, pp, KPrototypesOptions{Categorical: []int{2}, Gamma: 5})
+	assert.NoError(t, err)
+	assert.Equal(t, 5.0, pc.Gamma())
+	assert.Equal(t, 30.0, pc.Distance(observationValues[float64]{0, 0, 1}, observationValues[float64]{3, 4, 2}))
+
+	people, _, err := KPrototypes(3, listOfPeople(), KPrototypesOptions{Categorical: []int{3}})
+	assert.NoError(t, err)
+	for _, cl := range people.Clusters {
+		// The gender of each center is a category present in the dataset
+		assert.Contains(t, []float32{0, 1}, cl.Center.Values(3))
+	}
+
+	_, _, err = KPrototypes(2, pp, KPrototypesOptions{Categorical: []int{3}})
+	assert.ErrorIs(t, err, ErrInvalidCategorical)
+	_, _, err = KPrototypes(0, pp, opts)
+	assert.ErrorIs(t, err, ErrKMustBeGreaterThanZero)
+}
@@ -0,0 +1,172 @@
+package kmeans
+
+import "fmt"
+
+var ErrUnsatisfiableConstraints = fmt.Errorf("link constraints cannot be satisfied")
+
+// LinkConstraints declares pairs of observations, identified by their position in the
+// dataset, that must or cannot share a cluster. The zero value has no constraints.
+type LinkConstraints struct {
+	mustLink   map[int][]int
+	cannotLink map[int][]int
+}
+
+// NewLinkConstraints returns an empty set of constraints
+func NewLinkConstraints() *LinkConstraints {
+	return &LinkConstraints{}
+}
+
+// MustLink requires the i'th and j'th observations to share a cluster
+func (l *LinkConstraints) MustLink(i, j int) {
+	if l.mustLink == nil {
+		l.mustLink = make(map[int][]int)
+	}
+	l.mustLink[i] = append(l.mustLink[i], j)
+	l.mustLink[j] = append(l.mustLink[j], i)
+}
+
+// CannotLink requires the i'th and j'th observations to be in different clusters
+func (l *LinkConstraints) CannotLink(i, j int) {
+	if l.cannotLink == nil {
+		l.cannotLink = make(map[int][]int)
+	}
+	l.cannotLink[i] = append(l.cannotLink[i], j)
+	l.cannotLink[j] = append(l.cannotLink[j], i)
+}
+
+// validate returns an error if the constraints refer to observations beyond n or if
+// observations that must link, directly or through other observations, cannot link
+func (l *LinkConstraints) validate(n int) error {
+	for _, links := range []map[int][]int{l.mustLink, l.cannotLink} {
+		for i := range links {
+			if i < 0 || i >= n {
+				return fmt.Errorf("%w: observation %d is not in the dataset of %d observations",
+					ErrUnsatisfiableConstraints, i, n)
+			}
+		}
+	}
+	component := l.components(n)
+	for i, others := range l.cannotLink {
+		for _, j := range others {
+			if component[i] == component[j] {
+				return fmt.Errorf("%w: observations %d and %d cannot link but are must linked",
+					ErrUnsatisfiableConstraints, i, j)
+			}
+		}
+	}
+	return nil
+}
+
+// components labels every observation with the smallest index of the observations it
+// must link with, directly or transitively
+func (l *LinkConstraints) components(n int) []int {
+	component := make([]int, n)
+	for i := range component {
+		component[i] = -1
+	}
+	for i := range n {
+		if component[i] >= 0 {
+			continue
+		}
+		stack := []int{i}
+		component[i] = i
+		for len(stack) > 0 {
+			o := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			for _, j := range l.mustLink[o] {
+				if component[j] < 0 {
+					component[j] = i
+					stack = append(stack, j)
+				}
+			}
+		}
+	}
+	return component
+}
+
+// allows returns true if the i'th observation can join cluster ci given the clusters
+// already assigned, where -1 marks an observation that is not yet assigned
+func (l *LinkConstraints) allows(i, ci int, assignments []int) bool {
+	for _, j := range l.mustLink[i] {
+		if assignments[j] >= 0 && assignments[j] != ci {
+			return false
+		}
+	}
+	for _, j := range l.cannotLink[i] {
+		if assignments[j] == ci {
+			return false
+		}
+	}
+	return true
+}
+
+// NearestAllowed returns the index of the nearest cluster to point for which allowed returns
+// true or -1 if no cluster is allowed
+func (c Clusters[T]) NearestAllowed(point Observation[T], allowed func(cluster int) bool) int {
+	ci := -1
+	dist := -1.0
+	for i, cluster := range c {
+		if !allowed(i) {
+			continue
+		}
+		d := Distance(point, cluster.Center, cluster.Observations.d)
+		if dist < 0 || d < dist {
+			dist = d
+			ci = i
+		}
+	}
+	return ci
+}
+
+// FitLinkConstrained refines the clusters like Fit using COP-k-means. Observations are assigned
+// in dataset order to the NearestAllowed cluster that does not violate a constraint with the
+// observations already assigned during the pass. An error wrapping ErrUnsatisfiableConstraints
+// is returned when the constraints contradict each other or an observation has no allowed cluster.
+// Nil constraints place no restriction on the assignments.
+func (c Clusters[T]) FitLinkConstrained(dataset Observations[T], constraints *LinkConstraints, opts FitOptions) (FitResult, error) {
+	var r FitResult
+	if dataset.Degree() == 0 {
+		return r, ErrEmptyObservations
+	}
+	if len(c) == 0 {
+		return r, ErrKMustBeGreaterThanZero
+	}
+	if constraints == nil {
+		constraints = &LinkConstraints{}
+	}
+	n := 0
+	for range dataset.Observations() {
+		n++
+	}
+	if err := constraints.validate(n); err != nil {
+		return r, err
+	}
+	opts = opts.withDefaults()
+
+	assignments := make([]int, n)
+	for r.Iterations < opts.MaxIterations {
+		r.Iterations++
+		for i := range assignments {
+			assignments[i] = -1
+		}
+		c.clearObservations()
+		i := 0
+		for o := range dataset.Observations() {
+			ci := c.NearestAllowed(o, func(cluster int) bool {
+				return constraints.allows(i, cluster, assignments)
+			})
+			if ci < 0 {
+				return r, fmt.Errorf("%w: observation %d cannot join any of the %d clusters",
+					ErrUnsatisfiableConstraints, i, len(c))
+			}
+			assignments[i] = ci
+			c[ci].assign(o)
+			i++
+		}
+		if c.recenter() <= opts.Tolerance {
+			r.Converged = true
+			break
+		}
+	}
+	return r, nil
+}
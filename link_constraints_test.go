@@ -0,0 +1,71 @@
+package kmeans
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFitLinkConstrained(t *testing.T) {
+	pp := blobs([][]float64{{0, 0}, {10, 0}}, 20, 1)
+	constraints := NewLinkConstraints()
+	// Pull an observation from the second blob into the first and split the first blob
+	constraints.MustLink(0, 25)
+	constraints.CannotLink(1, 2)
+
+	cc, err := NewWithInitializer(2, pp, KMeansPlusPlus[float64])
+	assert.NoError(t, err)
+	r, err := cc.FitLinkConstrained(pp, constraints, DefaultFitOptions)
+	assert.NoError(t, err)
+	assert.Greater(t, r.Iterations, 0)
+
+	cluster := func(i int) int {
+		for ci, cl := range cc {
+			for _, o := range cl.Observations.ClusterObservations {
+				if &o.(observationValues[float64])[0] == &pp[i][0] {
+					return ci
+				}
+			}
+		}
+		return -1
+	}
+	assert.Equal(t, cluster(0), cluster(25))
+	assert.NotEqual(t, cluster(1), cluster(2))
+}
+
+func TestFitLinkConstrainedUnsatisfiable(t *testing.T) {
+	pp := blobs([][]float64{{0, 0}, {10, 0}}, 20, 1)
+	cc, err := New(2, pp)
+	assert.NoError(t, err)
+
+	contradiction := NewLinkConstraints()
+	contradiction.MustLink(0, 1)
+	contradiction.MustLink(1, 2)
+	contradiction.CannotLink(0, 2)
+	_, err = cc.FitLinkConstrained(pp, contradiction, DefaultFitOptions)
+	assert.ErrorIs(t, err, ErrUnsatisfiableConstraints)
+
+	// Three observations that cannot share a cluster do not fit in two clusters
+	tooFew := NewLinkConstraints()
+	tooFew.CannotLink(0, 1)
+	tooFew.CannotLink(1, 2)
+	tooFew.CannotLink(0, 2)
+	_, err = cc.FitLinkConstrained(pp, tooFew, DefaultFitOptions)
+	assert.ErrorIs(t, err, ErrUnsatisfiableConstraints)
+
+	outOfRange := NewLinkConstraints()
+	outOfRange.MustLink(0, 100)
+	_, err = cc.FitLinkConstrained(pp, outOfRange, DefaultFitOptions)
+	assert.ErrorIs(t, err, ErrUnsatisfiableConstraints)
+
+	// Without constraints the clusters are fitted like Fit
+	_, err = cc.FitLinkConstrained(pp, nil, DefaultFitOptions)
+	assert.NoError(t, err)
+
+	// The zero value accepts constraints
+	var zero LinkConstraints
+	zero.MustLink(0, 1)
+	zero.CannotLink(0, 2)
+	_, err = cc.FitLinkConstrained(pp, &zero, DefaultFitOptions)
+	assert.NoError(t, err)
+}
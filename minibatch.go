@@ -0,0 +1,80 @@
+package kmeans
+
+// MiniBatchOptions controls the training performed by FitMiniBatch
+type MiniBatchOptions struct {
+	// BatchSize is the number of observations sampled for each iteration
+	BatchSize int
+	// MaxIterations is the number of batches used to train the centers
+	MaxIterations int
+	// Tolerance is the largest movement of any center during a batch, as measured
+	// by Distance, that is still considered converged. A negative tolerance always
+	// runs MaxIterations batches.
+	Tolerance float64
+}
+
+// DefaultMiniBatchOptions are reasonable limits for most datasets. Zero values for
+// BatchSize or MaxIterations are replaced with these defaults.
+var DefaultMiniBatchOptions = MiniBatchOptions{
+	BatchSize:     100,
+	MaxIterations: 100,
+	Tolerance:     -1,
+}
+
+func (o MiniBatchOptions) withDefaults() MiniBatchOptions {
+	if o.BatchSize <= 0 {
+		o.BatchSize = DefaultMiniBatchOptions.BatchSize
+	}
+	if o.MaxIterations <= 0 {
+		o.MaxIterations = DefaultMiniBatchOptions.MaxIterations
+	}
+	return o
+}
+
+// FitMiniBatch trains the cluster centers using mini-batch k-means. Each iteration draws a
+// random batch from the dataset with SelectRandomObservations, assigns the batch to the
+// Nearest centers and then moves each center towards its batch observations with a learning
+// rate of w/n, where w is the weight of the observation and n is the total weight of the
+// observations the center has received.
+//
+// Only the batches are held in memory, so observations are not retained by the clusters.
+// Use Nearest to assign observations once the centers are trained.
+func (c Clusters[T]) FitMiniBatch(dataset Observations[T], opts MiniBatchOptions) (FitResult, error) {
+	var r FitResult
+	if dataset.Degree() == 0 {
+		return r, ErrEmptyObservations
+	}
+	if len(c) == 0 {
+		return r, ErrKMustBeGreaterThanZero
+	}
+	opts = opts.withDefaults()
+	c.clearObservations()
+
+	weights := make([]float64, len(c))
+	nearest := make([]int, opts.BatchSize)
+	previous := make([]Observation[T], len(c))
+	for r.Iterations < opts.MaxIterations {
+		r.Iterations++
+		batch := SelectRandomObservations(dataset, opts.BatchSize)
+		for i, o := range batch {
+			nearest[i] = c.Nearest(o)
+		}
+		for i := range c {
+			previous[i] = c[i].Center
+		}
+		for i, o := range batch {
+			j := nearest[i]
+			weights[j] += ObservationWeight(o)
+			c[j].addToMean(o, weights[j])
+		}
+
+		var shift float64
+		for i := range c {
+			shift = max(shift, Distance(previous[i], c[i].Center, dataset.Degree()))
+		}
+		if shift <= opts.Tolerance {
+			r.Converged = true
+			break
+		}
+	}
+	return r, nil
+}
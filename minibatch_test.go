@@ -0,0 +1,29 @@
+package kmeans
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFitMiniBatch(t *testing.T) {
+	oo := NewNormalizeObservationAdapter(listOfPeople(), nil)
+	cc, err := NewWithInitializer(3, oo, KMeansPlusPlus[float32])
+	assert.NoError(t, err)
+
+	r, err := cc.FitMiniBatch(oo, MiniBatchOptions{BatchSize: 10, MaxIterations: 50, Tolerance: -1})
+	assert.NoError(t, err)
+	assert.Equal(t, 50, r.Iterations)
+	assert.False(t, r.Converged)
+	for _, cl := range cc {
+		assert.NotNil(t, cl.Center)
+		assert.Empty(t, cl.Observations.ClusterObservations)
+	}
+
+	r, err = cc.FitMiniBatch(oo, MiniBatchOptions{BatchSize: 100, Tolerance: 1})
+	assert.NoError(t, err)
+	assert.True(t, r.Converged)
+
+	_, err = Clusters[float32]{}.FitMiniBatch(oo, DefaultMiniBatchOptions)
+	assert.ErrorIs(t, err, ErrKMustBeGreaterThanZero)
+}
@@ -21,6 +21,22 @@ type Observation[T Number] interface {
 	Values(i int) T
 }
 
+// WeightedObservation is an Observation that stands for Weight() observations, such as a
+// pre-aggregated row. Observations that do not implement it have a weight of 1. Weights must
+// not be negative and observations with a weight of 0 are clustered without moving any center.
+type WeightedObservation[T Number] interface {
+	Observation[T]
+	Weight() float64
+}
+
+// ObservationWeight returns the weight of a WeightedObservation or 1 for any other Observation
+func ObservationWeight[T Number](o Observation[T]) float64 {
+	if w, ok := o.(WeightedObservation[T]); ok {
+		return w.Weight()
+	}
+	return 1
+}
+
 // Observations is a collection of Observation objects which may be provided as
 // a sequence to prevent the requirement that caller implementor use a slice
 type Observations[T Number] interface {
@@ -48,6 +64,11 @@ func (o *ObservationList[T]) All() iter.Seq[Observation[T]] {
 	return slices.Values(o.ClusterObservations)
 }
 
+// Observations allows an ObservationList to be used as an Observations dataset
+func (o *ObservationList[T]) Observations() iter.Seq[Observation[T]] {
+	return o.All()
+}
+
 func (o *ObservationList[T]) Degree() int {
 	return o.d
 }
@@ -109,15 +130,15 @@ func ObservationRange[T Number](oo iter.Seq[Observation[T]], degree int) (observ
 }
 
 // ObservationSum returns a slice the same size as the Degree of the observation. Each entry in the slice
-// is the sum of all the values for that slice's index into their `Value()`. The count of the number of entries are
-// also returned.
-func ObservationSum[T Number](oo iter.Seq[Observation[T]], degree int) (sum []T, count int) {
-	count = 0
-	sum = make([]T, degree)
+// is the sum of all the values for that slice's index into their `Value()` scaled by the ObservationWeight.
+// The total weight of the observations, which is their count without WeightedObservations, is also returned.
+func ObservationSum[T Number](oo iter.Seq[Observation[T]], degree int) (sum []float64, weight float64) {
+	sum = make([]float64, degree)
 	for o := range oo {
-		count++
+		w := ObservationWeight(o)
+		weight += w
 		for i := range degree {
-			sum[i] += o.Values(i)
+			sum[i] += w * float64(o.Values(i))
 		}
 	}
 	return
@@ -132,16 +153,23 @@ func Distance[T Number](o1, o2 Observation[T], degree int) float64 {
 	return r
 }
 
+// euclideanDistance returns the square root of Distance, which unlike Distance
+// satisfies the triangle inequality
+func euclideanDistance[T Number](o1, o2 Observation[T], degree int) float64 {
+	return math.Sqrt(Distance(o1, o2, degree))
+}
+
+// Center returns the mean of the observations weighted by their ObservationWeight
 func Center[T Number](os iter.Seq[Observation[T]], degree int) ([]T, error) {
 
-	osSum, count := ObservationSum(os, degree)
-	if count == 0 {
+	osSum, weight := ObservationSum(os, degree)
+	if weight == 0 {
 		return nil, ErrEmptyObservations
 	}
 
 	mean := make([]T, 0, len(osSum))
 	for _, v := range osSum {
-		mean = append(mean, T(float64(v)/float64(count)))
+		mean = append(mean, T(v/weight))
 	}
 	return mean, nil
 }
@@ -3,6 +3,7 @@ package kmeans
 import (
 	"fmt"
 	"iter"
+	"math/rand"
 	"testing"
 	"time"
 
@@ -119,9 +120,9 @@ func TestObservationRange(t *testing.T) {
 
 func TestObservationSum(t *testing.T) {
 	personObservations := listOfPeople()
-	sums, c := ObservationSum(personObservations.Observations(), personObservations.Degree())
+	sums, weight := ObservationSum(personObservations.Observations(), personObservations.Degree())
 	assert.Len(t, sums, personObservations.Degree())
-	assert.Len(t, personObservations, c)
+	assert.Equal(t, float64(len(personObservations)), weight)
 }
 
 func TestNormalizeObservations(t *testing.T) {
@@ -264,3 +265,133 @@ func TestBestClustersScaledNormalized(t *testing.T) {
 		fmt.Println(i+2, ",", v)
 	}
 }
+
+type points [][]float64
+
+func (p points) Observations() iter.Seq[Observation[float64]] {
+	return func(yield func(Observation[float64]) bool) {
+		for _, o := range p {
+			if !yield(observationValues[float64](o)) {
+				return
+			}
+		}
+	}
+}
+
+func (p points) Degree() int {
+	return len(p[0])
+}
+
+// blobs generates n points normally distributed around each of the centers
+func blobs(centers [][]float64, n int, spread float64) points {
+	r := rand.New(rand.NewSource(1))
+	var pp points
+	for _, c := range centers {
+		for range n {
+			p := make([]float64, len(c))
+			for i := range c {
+				p[i] = c[i] + r.NormFloat64()*spread
+			}
+			pp = append(pp, p)
+		}
+	}
+	return pp
+}
+
+type weightedPoint struct {
+	observationValues[float64]
+	weight float64
+}
+
+func (w weightedPoint) Weight() float64 {
+	return w.weight
+}
+
+type weightedPoints []weightedPoint
+
+func (p weightedPoints) Observations() iter.Seq[Observation[float64]] {
+	return func(yield func(Observation[float64]) bool) {
+		for _, o := range p {
+			if !yield(o) {
+				return
+			}
+		}
+	}
+}
+
+func (p weightedPoints) Degree() int {
+	return len(p[0].observationValues)
+}
+
+func TestWeightedObservations(t *testing.T) {
+	weighted := weightedPoints{
+		{observationValues[float64]{0, 0}, 3},
+		{observationValues[float64]{4, 8}, 1},
+	}
+	expanded := points{{0, 0}, {0, 0}, {0, 0}, {4, 8}}
+
+	assert.Equal(t, 3.0, ObservationWeight[float64](weighted[0]))
+	assert.Equal(t, 1.0, ObservationWeight[float64](observationValues[float64]{1, 1}))
+
+	center, err := Center(weighted.Observations(), 2)
+	assert.NoError(t, err)
+	assert.Equal(t, []float64{1, 2}, center)
+	sum, weight := ObservationSum(weighted.Observations(), 2)
+	assert.Equal(t, []float64{4, 8}, sum)
+	assert.Equal(t, 4.0, weight)
+	sum, weight = ObservationSum(weightedPoints{{observationValues[float64]{4, 8}, 2}}.Observations(), 2)
+	assert.Equal(t, []float64{8, 16}, sum)
+	assert.Equal(t, 2.0, weight)
+
+	wc := Cluster[float64]{Observations: NewObservationList[float64](2)}
+	ec := Cluster[float64]{Observations: NewObservationList[float64](2)}
+	for o := range weighted.Observations() {
+		wc.Append(o)
+	}
+	for o := range expanded.Observations() {
+		ec.Append(o)
+	}
+	assert.Equal(t, ec.Center, wc.Center)
+	assert.Equal(t, ec.SumOfDistance(), wc.SumOfDistance())
+
+	// Observations without weight do not move the center
+	zc := Cluster[float64]{Center: observationValues[float64]{1, 1}, Observations: NewObservationList[float64](2)}
+	zc.Append(weightedPoint{observationValues[float64]{4, 8}, 0})
+	assert.Equal(t, observationValues[float64]{1, 1}, zc.Center)
+
+	// Heavy observations are always selected as the first center
+	heavy := weightedPoints{
+		{observationValues[float64]{0, 0}, 0},
+		{observationValues[float64]{1, 1}, 1},
+	}
+	for range 10 {
+		assert.Equal(t, heavy[1], KMeansPlusPlus(heavy, 1)[0])
+		assert.Equal(t, []Observation[float64]{heavy[1]}, SelectWeightedObservations(heavy, 2))
+	}
+
+	// The default initializer selects in proportion to weight
+	selected := 0
+	for range 20000 {
+		if ObservationWeight(SelectWeightedObservations(weighted, 1)[0]) == 3 {
+			selected++
+		}
+	}
+	assert.InDelta(t, 15000, selected, 500)
+}
+
+func TestSelectRandomObservationsUniform(t *testing.T) {
+	pp := points{{0}, {1}, {2}, {3}}
+	for k := 1; k <= 3; k++ {
+		counts := make([]int, len(pp))
+		trials := 20000
+		for range trials {
+			for _, o := range SelectRandomObservations(pp, k) {
+				counts[int(o.Values(0))]++
+			}
+		}
+		expected := trials * k / len(pp)
+		for _, c := range counts {
+			assert.InDelta(t, expected, c, float64(expected)/10)
+		}
+	}
+}
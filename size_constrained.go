@@ -0,0 +1,142 @@
+package kmeans
+
+import (
+	"fmt"
+	"slices"
+)
+
+var ErrInfeasibleSizeConstraints = fmt.Errorf("cluster size constraints cannot be satisfied")
+
+// SizeConstraints bounds the number of observations in every cluster
+type SizeConstraints struct {
+	// Min is the fewest observations a cluster may hold
+	Min int
+	// Max is the most observations a cluster may hold, zero for no limit
+	Max int
+}
+
+// feasible returns an error if n observations cannot be divided into k clusters
+// within the constraints
+func (s SizeConstraints) feasible(n, k int) error {
+	if s.Min < 0 || s.Max < 0 || (s.Max > 0 && s.Min > s.Max) {
+		return fmt.Errorf("%w: invalid bounds min %d max %d", ErrInfeasibleSizeConstraints, s.Min, s.Max)
+	}
+	if s.Min*k > n {
+		return fmt.Errorf("%w: %d observations cannot fill %d clusters with at least %d each",
+			ErrInfeasibleSizeConstraints, n, k, s.Min)
+	}
+	if s.Max > 0 && s.Max*k < n {
+		return fmt.Errorf("%w: %d observations do not fit in %d clusters with at most %d each",
+			ErrInfeasibleSizeConstraints, n, k, s.Max)
+	}
+	return nil
+}
+
+// FitSizeConstrained refines the clusters like Fit while keeping the number of observations in
+// every cluster within the constraints. Each pass greedily assigns the closest observation and
+// cluster pairs, first until every cluster holds the minimum and then without exceeding the
+// maximum. Observations are then moved or swapped between clusters while that reduces their
+// distance to their centers and keeps the clusters within the constraints. An error wrapping
+// ErrInfeasibleSizeConstraints is returned when the constraints cannot be satisfied.
+func (c Clusters[T]) FitSizeConstrained(dataset Observations[T], sizes SizeConstraints, opts FitOptions) (FitResult, error) {
+	var r FitResult
+	if dataset.Degree() == 0 {
+		return r, ErrEmptyObservations
+	}
+	if len(c) == 0 {
+		return r, ErrKMustBeGreaterThanZero
+	}
+	var oo []Observation[T]
+	for o := range dataset.Observations() {
+		oo = append(oo, o)
+	}
+	if err := sizes.feasible(len(oo), len(c)); err != nil {
+		return r, err
+	}
+	if sizes.Max == 0 {
+		sizes.Max = len(oo)
+	}
+	opts = opts.withDefaults()
+
+	for r.Iterations < opts.MaxIterations {
+		r.Iterations++
+		assignments := c.assignWithinSizes(oo, sizes)
+		c.clearObservations()
+		for i, o := range oo {
+			c[assignments[i]].assign(o)
+		}
+		if c.recenter() <= opts.Tolerance {
+			r.Converged = true
+			break
+		}
+	}
+	return r, nil
+}
+
+// assignWithinSizes returns the cluster of each observation with the size of every
+// cluster between the constraints
+func (c Clusters[T]) assignWithinSizes(oo []Observation[T], sizes SizeConstraints) []int {
+	k := len(c)
+	dist := make([][]float64, len(oo))
+	type pair struct {
+		o, c int
+	}
+	pairs := make([]pair, 0, len(oo)*k)
+	for i, o := range oo {
+		dist[i] = make([]float64, k)
+		for j := range c {
+			dist[i][j] = Distance(o, c[j].Center, c[j].Observations.d)
+			pairs = append(pairs, pair{i, j})
+		}
+	}
+	slices.SortStableFunc(pairs, func(a, b pair) int {
+		if dist[a.o][a.c] < dist[b.o][b.c] {
+			return -1
+		}
+		if dist[a.o][a.c] > dist[b.o][b.c] {
+			return 1
+		}
+		return 0
+	})
+
+	assignments := make([]int, len(oo))
+	for i := range assignments {
+		assignments[i] = -1
+	}
+	counts := make([]int, k)
+	// Fill every cluster to the minimum and then the remaining observations up to the maximum
+	for _, limit := range []int{sizes.Min, sizes.Max} {
+		for _, p := range pairs {
+			if assignments[p.o] < 0 && counts[p.c] < limit {
+				assignments[p.o] = p.c
+				counts[p.c]++
+			}
+		}
+	}
+
+	for improved := true; improved; {
+		improved = false
+		for i := range oo {
+			a := assignments[i]
+			for j := range k {
+				if dist[i][j] < dist[i][a] && counts[j] < sizes.Max && counts[a] > sizes.Min {
+					counts[a]--
+					counts[j]++
+					assignments[i] = j
+					a = j
+					improved = true
+				}
+			}
+		}
+		for i := range oo {
+			for l := i + 1; l < len(oo); l++ {
+				a, b := assignments[i], assignments[l]
+				if a != b && dist[i][b]+dist[l][a] < dist[i][a]+dist[l][b] {
+					assignments[i], assignments[l] = b, a
+					improved = true
+				}
+			}
+		}
+	}
+	return assignments
+}
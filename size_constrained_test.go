@@ -0,0 +1,39 @@
+package kmeans
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFitSizeConstrained(t *testing.T) {
+	oo := NormalizeObservations(listOfPeople())
+	n := len(listOfPeople())
+	for _, sizes := range []SizeConstraints{{Min: 10, Max: 12}, {Min: 5}, {Max: 15}, {Min: 11, Max: 11}} {
+		cc, err := NewWithInitializer(4, oo, KMeansPlusPlus[float64])
+		assert.NoError(t, err)
+		r, err := cc.FitSizeConstrained(oo, sizes, DefaultFitOptions)
+		assert.NoError(t, err)
+		assert.Greater(t, r.Iterations, 0)
+
+		total := 0
+		for _, cl := range cc {
+			size := len(cl.Observations.ClusterObservations)
+			total += size
+			assert.GreaterOrEqual(t, size, sizes.Min)
+			if sizes.Max > 0 {
+				assert.LessOrEqual(t, size, sizes.Max)
+			}
+		}
+		assert.Equal(t, n, total)
+	}
+
+	cc, err := New(4, oo)
+	assert.NoError(t, err)
+	_, err = cc.FitSizeConstrained(oo, SizeConstraints{Min: 12}, DefaultFitOptions)
+	assert.ErrorIs(t, err, ErrInfeasibleSizeConstraints)
+	_, err = cc.FitSizeConstrained(oo, SizeConstraints{Max: 10}, DefaultFitOptions)
+	assert.ErrorIs(t, err, ErrInfeasibleSizeConstraints)
+	_, err = cc.FitSizeConstrained(oo, SizeConstraints{Min: 5, Max: 4}, DefaultFitOptions)
+	assert.ErrorIs(t, err, ErrInfeasibleSizeConstraints)
+}
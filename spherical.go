@@ -0,0 +1,165 @@
+package kmeans
+
+import (
+	"fmt"
+	"math"
+)
+
+var ErrIntegerSphericalCenters = fmt.Errorf("unit length centers cannot be represented by an integer type")
+
+// UnitNormalizeObservations scales every observation to unit length so that the
+// observations only differ by direction. Zero length observations are left as zero.
+func UnitNormalizeObservations[O Number](oo Observations[O]) Observations[float64] {
+	var noo observations[float64, O]
+	for o := range oo.Observations() {
+		nvv := make([]float64, oo.Degree())
+		for i := range oo.Degree() {
+			nvv[i] = float64(o.Values(i))
+		}
+		unitNormalize(nvv)
+		noo = append(noo, NormalizedObservation[float64, O]{
+			observation: nvv,
+			Original:    &o,
+		})
+	}
+	return noo
+}
+
+// CosineDistance returns one minus the cosine similarity of two observations, which is 0
+// for observations pointing in the same direction and 2 for opposite directions
+func CosineDistance[T Number](o1, o2 Observation[T], degree int) float64 {
+	var dot, n1, n2 float64
+	for i := range degree {
+		v1, v2 := float64(o1.Values(i)), float64(o2.Values(i))
+		dot += v1 * v2
+		n1 += v1 * v1
+		n2 += v2 * v2
+	}
+	if n1 == 0 || n2 == 0 {
+		return 1
+	}
+	// Rounding may push parallel observations slightly below zero
+	return max(0, 1-dot/math.Sqrt(n1*n2))
+}
+
+// NearestCosine returns the index of the cluster with the smallest CosineDistance to point
+func (c Clusters[T]) NearestCosine(point Observation[T]) int {
+	var ci int
+	dist := -1.0
+	for i, cluster := range c {
+		d := CosineDistance(point, cluster.Center, cluster.Observations.d)
+		if dist < 0 || d < dist {
+			dist = d
+			ci = i
+		}
+	}
+	return ci
+}
+
+// SumCosineDistance computes the sum of the CosineDistance of all the observations from
+// the center of their cluster
+func (c Clusters[T]) SumCosineDistance() float64 {
+	var d float64
+	for _, cl := range c {
+		for _, o := range cl.Observations.ClusterObservations {
+			d += CosineDistance(o, cl.Center, cl.Observations.d)
+		}
+	}
+	return d
+}
+
+// FitSpherical refines the clusters using spherical k-means. Each pass assigns every
+// observation to the cluster with the NearestCosine center and moves each center to the
+// direction of the weighted mean of its observations scaled to unit length, so long
+// observations do not dominate. ErrIntegerSphericalCenters is returned for integer types.
+func (c Clusters[T]) FitSpherical(dataset Observations[T], opts FitOptions) (FitResult, error) {
+	var r FitResult
+	if dataset.Degree() == 0 {
+		return r, ErrEmptyObservations
+	}
+	if len(c) == 0 {
+		return r, ErrKMustBeGreaterThanZero
+	}
+	if half := 0.5; T(half) == 0 {
+		return r, ErrIntegerSphericalCenters
+	}
+	opts = opts.withDefaults()
+	for i := range c {
+		c[i].unitNormalizeCenter()
+	}
+
+	for r.Iterations < opts.MaxIterations {
+		r.Iterations++
+		c.clearObservations()
+		for o := range dataset.Observations() {
+			c[c.NearestCosine(o)].assign(o)
+		}
+		previous := c.centers()
+		for i := range c {
+			c[i].sphericalRecenter()
+		}
+		var shift float64
+		for i := range c {
+			shift = max(shift, Distance(previous[i], c[i].Center, dataset.Degree()))
+		}
+		if shift <= opts.Tolerance {
+			r.Converged = true
+			break
+		}
+	}
+	return r, nil
+}
+
+// sphericalRecenter moves the center to the unit length mean of the unit length observations
+func (c *Cluster[T]) sphericalRecenter() {
+	if len(c.Observations.ClusterObservations) == 0 {
+		return
+	}
+	sum := make([]float64, c.Observations.d)
+	vv := make([]float64, c.Observations.d)
+	for _, o := range c.Observations.ClusterObservations {
+		for i := range vv {
+			vv[i] = float64(o.Values(i))
+		}
+		unitNormalize(vv)
+		w := ObservationWeight(o)
+		for i, v := range vv {
+			sum[i] += w * v
+		}
+	}
+	unitNormalize(sum)
+	center := make([]T, len(sum))
+	for i, v := range sum {
+		center[i] = T(v)
+	}
+	c.Center = centerObservation[T](center)
+}
+
+// unitNormalizeCenter scales the center of the cluster to unit length
+func (c *Cluster[T]) unitNormalizeCenter() {
+	vv := make([]float64, c.Observations.d)
+	for i := range vv {
+		vv[i] = float64(c.Center.Values(i))
+	}
+	unitNormalize(vv)
+	center := make([]T, len(vv))
+	for i, v := range vv {
+		center[i] = T(v)
+	}
+	c.Center = centerObservation[T](center)
+}
+
+// unitNormalize scales vv to unit length unless it has zero length
+func unitNormalize(vv []float64) {
+	var n float64
+	for _, v := range vv {
+		n += v * v
+	}
+	if n == 0 {
+		return
+	}
+	n = math.Sqrt(n)
+	for i := range vv {
+		vv[i] /= n
+	}
+}
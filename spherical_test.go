@@ -0,0 +1,58 @@
+package kmeans
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnitNormalizeObservations(t *testing.T) {
+	oo := UnitNormalizeObservations(listOfPeople())
+	for o := range oo.Observations() {
+		var n float64
+		for i := range oo.Degree() {
+			n += o.Values(i) * o.Values(i)
+		}
+		assert.InDelta(t, 1.0, n, 1e-9)
+	}
+	assert.InDelta(t, 0.0, CosineDistance(observationValues[float64]{1, 1}, observationValues[float64]{3, 3}, 2), 1e-12)
+	assert.InDelta(t, 1.0, CosineDistance(observationValues[float64]{1, 0}, observationValues[float64]{0, 3}, 2), 1e-12)
+	assert.InDelta(t, 2.0, CosineDistance(observationValues[float64]{1, 0}, observationValues[float64]{-2, 0}, 2), 1e-12)
+}
+
+func TestFitSpherical(t *testing.T) {
+	// Groups of vectors pointing in three directions with very different lengths
+	var pp points
+	for i := range 60 {
+		angle := float64(i%3)*2*math.Pi/3 + float64(i%5)*0.01
+		length := float64(1 + i)
+		pp = append(pp, []float64{length * math.Cos(angle), length * math.Sin(angle)})
+	}
+	oo := UnitNormalizeObservations(pp)
+
+	cc, err := NewWithInitializer(3, oo, KMeansPlusPlus[float64])
+	assert.NoError(t, err)
+	r, err := cc.FitSpherical(oo, DefaultFitOptions)
+	assert.NoError(t, err)
+	assert.True(t, r.Converged)
+	assert.Less(t, cc.SumCosineDistance(), 0.01)
+	for _, cl := range cc {
+		assert.Len(t, cl.Observations.ClusterObservations, 20)
+		assert.InDelta(t, 1.0, Distance(cl.Center, observationValues[float64]{0, 0}, 2), 1e-9)
+	}
+
+	_, err = Clusters[float64]{}.FitSpherical(oo, DefaultFitOptions)
+	assert.ErrorIs(t, err, ErrKMustBeGreaterThanZero)
+
+	// Long observations do not pull the center towards their direction
+	raw := points{{10, 0}, {0, 1}}
+	cc = newClusters[float64](2, []Observation[float64]{observationValues[float64]{1, 0}})
+	_, err = cc.FitSpherical(raw, DefaultFitOptions)
+	assert.NoError(t, err)
+	assert.InDelta(t, cc[0].Center.Values(0), cc[0].Center.Values(1), 1e-12)
+
+	ints := gridPoints(1, 10, 8)
+	_, err = newClusters(2, ints.ClusterObservations[:2]).FitSpherical(ints, DefaultFitOptions)
+	assert.ErrorIs(t, err, ErrIntegerSphericalCenters)
+}
@@ -0,0 +1,127 @@
+package kmeans
+
+import (
+	"fmt"
+	"iter"
+)
+
+var ErrInvalidDecay = fmt.Errorf("decay must be greater than 0 and at most 1")
+
+// StreamingOptions controls how StreamingClusters adapt to new observations
+type StreamingOptions struct {
+	// Decay is the forgetting factor applied to the weight of every center for each new
+	// observation. 1 never forgets, so each center is the mean of the observations it received,
+	// while smaller values let the centers follow data that drifts over time. Zero is treated as 1.
+	Decay float64
+}
+
+// StreamingClusters is a long-lived online k-means clusterer. Observations are added one at a time
+// and only move their nearest center, so memory use does not grow with the number of observations.
+type StreamingClusters[T Number] struct {
+	centers [][]float64
+	weights []float64
+	k       int
+	degree  int
+	decay   float64
+}
+
+// NewStreamingClusters creates an online clusterer for k clusters of observations with the degree.
+// The first k observations added become the initial centers.
+func NewStreamingClusters[T Number](k, degree int, opts StreamingOptions) (*StreamingClusters[T], error) {
+	if degree == 0 {
+		return nil, ErrEmptyObservations
+	}
+	if k == 0 {
+		return nil, ErrKMustBeGreaterThanZero
+	}
+	if opts.Decay == 0 {
+		opts.Decay = 1
+	}
+	if opts.Decay < 0 || opts.Decay > 1 {
+		return nil, ErrInvalidDecay
+	}
+	return &StreamingClusters[T]{
+		k:      k,
+		degree: degree,
+		decay:  opts.Decay,
+	}, nil
+}
+
+// Add moves the nearest center towards the observation in proportion to the observation's
+// ObservationWeight and returns the index of that center. The observation is not retained.
+func (s *StreamingClusters[T]) Add(o Observation[T]) int {
+	w := ObservationWeight(o)
+	if len(s.centers) < s.k {
+		center := make([]float64, s.degree)
+		for i := range center {
+			center[i] = float64(o.Values(i))
+		}
+		s.centers = append(s.centers, center)
+		s.weights = append(s.weights, w)
+		return len(s.centers) - 1
+	}
+
+	if s.decay < 1 {
+		for j := range s.weights {
+			s.weights[j] *= s.decay
+		}
+	}
+	j := s.Nearest(o)
+	s.weights[j] += w
+	if s.weights[j] == 0 {
+		return j
+	}
+	rate := w / s.weights[j]
+	for i, v := range s.centers[j] {
+		s.centers[j][i] = v + rate*(float64(o.Values(i))-v)
+	}
+	return j
+}
+
+// AddAll adds every observation in the sequence
+func (s *StreamingClusters[T]) AddAll(oo iter.Seq[Observation[T]]) {
+	for o := range oo {
+		s.Add(o)
+	}
+}
+
+// Nearest returns the index of the center nearest to the observation
+func (s *StreamingClusters[T]) Nearest(o Observation[T]) int {
+	ci := 0
+	dist := -1.0
+	for j, center := range s.centers {
+		var d float64
+		for i, v := range center {
+			d += (float64(o.Values(i)) - v) * (float64(o.Values(i)) - v)
+		}
+		if dist < 0 || d < dist {
+			dist = d
+			ci = j
+		}
+	}
+	return ci
+}
+
+// Centers returns a copy of the current centers
+func (s *StreamingClusters[T]) Centers() []Observation[T] {
+	centers := make([]Observation[T], len(s.centers))
+	for j, center := range s.centers {
+		values := make([]T, s.degree)
+		for i, v := range center {
+			values[i] = T(v)
+		}
+		centers[j] = centerObservation[T](values)
+	}
+	return centers
+}
+
+// Weights returns the decayed total weight of the observations received by each center
+func (s *StreamingClusters[T]) Weights() []float64 {
+	return append([]float64(nil), s.weights...)
+}
+
+// Clusters returns a Clusters around the current centers without any observations, which can be
+// used with Nearest or refined with Fit
+func (s *StreamingClusters[T]) Clusters() Clusters[T] {
+	return newClusters(s.degree, s.Centers())
+}
@@ -0,0 +1,50 @@
+package kmeans
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStreamingClusters(t *testing.T) {
+	s, err := NewStreamingClusters[float64](2, 2, StreamingOptions{})
+	assert.NoError(t, err)
+	pp := blobs([][]float64{{0, 0}, {10, 10}}, 200, 1)
+	s.Add(observationValues[float64](pp[0]))
+	s.Add(observationValues[float64](pp[len(pp)-1]))
+	s.AddAll(pp.Observations())
+
+	centers := s.Centers()
+	assert.Len(t, centers, 2)
+	near := s.Nearest(observationValues[float64]{0, 0})
+	far := s.Nearest(observationValues[float64]{10, 10})
+	assert.NotEqual(t, near, far)
+	assert.InDelta(t, 0, centers[near].Values(0), 0.5)
+	assert.InDelta(t, 10, centers[far].Values(1), 0.5)
+	assert.InDelta(t, 402.0, s.Weights()[0]+s.Weights()[1], 1e-9)
+
+	cc := s.Clusters()
+	assert.Len(t, cc, 2)
+	assert.Equal(t, near, cc.Nearest(observationValues[float64]{0, 0}))
+}
+
+func TestStreamingClustersDecay(t *testing.T) {
+	s, err := NewStreamingClusters[float64](1, 1, StreamingOptions{Decay: 0.9})
+	assert.NoError(t, err)
+	// The center follows observations that drift away from the start
+	for range 100 {
+		s.Add(observationValues[float64]{0})
+	}
+	for range 100 {
+		s.Add(observationValues[float64]{10})
+	}
+	assert.InDelta(t, 10, s.Centers()[0].Values(0), 1e-3)
+	assert.Less(t, s.Weights()[0], 10.0+1e-9)
+	assert.False(t, math.IsNaN(s.Weights()[0]))
+
+	_, err = NewStreamingClusters[float64](1, 1, StreamingOptions{Decay: 1.5})
+	assert.ErrorIs(t, err, ErrInvalidDecay)
+	_, err = NewStreamingClusters[float64](0, 1, StreamingOptions{})
+	assert.ErrorIs(t, err, ErrKMustBeGreaterThanZero)
+}
@@ -0,0 +1,70 @@
+package kmeans
+
+import (
+	"cmp"
+	"fmt"
+	"math"
+	"slices"
+)
+
+var ErrInvalidTrim = fmt.Errorf("trim must be at least 0 and less than 1")
+
+// TrimmedResult reports how the refinement of trimmed clusters ended and which
+// observations were trimmed
+type TrimmedResult[T Number] struct {
+	FitResult
+	// Outliers are the observations trimmed during the final pass, farthest first
+	Outliers []Observation[T]
+}
+
+// FitTrimmed refines the clusters like Fit while ignoring the ceil(trim * n) observations
+// farthest from their nearest center on each pass, so that a few extreme observations cannot
+// drag the centers. The observations trimmed during the final pass are returned as Outliers.
+func (c Clusters[T]) FitTrimmed(dataset Observations[T], trim float64, opts FitOptions) (TrimmedResult[T], error) {
+	var r TrimmedResult[T]
+	if dataset.Degree() == 0 {
+		return r, ErrEmptyObservations
+	}
+	if len(c) == 0 {
+		return r, ErrKMustBeGreaterThanZero
+	}
+	if trim < 0 || trim >= 1 {
+		return r, ErrInvalidTrim
+	}
+	var oo []Observation[T]
+	for o := range dataset.Observations() {
+		oo = append(oo, o)
+	}
+	opts = opts.withDefaults()
+	degree := dataset.Degree()
+	trimmed := int(math.Ceil(trim * float64(len(oo))))
+
+	nearest := make([]int, len(oo))
+	distances := make([]float64, len(oo))
+	order := make([]int, len(oo))
+	for r.Iterations < opts.MaxIterations {
+		r.Iterations++
+		for i, o := range oo {
+			nearest[i] = c.Nearest(o)
+			distances[i] = Distance(o, c[nearest[i]].Center, degree)
+			order[i] = i
+		}
+		slices.SortStableFunc(order, func(a, b int) int {
+			return cmp.Compare(distances[b], distances[a])
+		})
+
+		c.clearObservations()
+		r.Outliers = r.Outliers[:0]
+		for _, i := range order[:trimmed] {
+			r.Outliers = append(r.Outliers, oo[i])
+		}
+		for _, i := range order[trimmed:] {
+			c[nearest[i]].assign(oo[i])
+		}
+		if c.recenter() <= opts.Tolerance {
+			r.Converged = true
+			break
+		}
+	}
+	return r, nil
+}
@@ -0,0 +1,36 @@
+package kmeans
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFitTrimmed(t *testing.T) {
+	centers := [][]float64{{0, 0}, {10, 0}}
+	pp := blobs(centers, 100, 1)
+	outliers := points{{100, 100}, {-100, 100}, {100, -100}, {-90, -90}}
+	pp = append(pp, outliers...)
+
+	cc, err := NewWithInitializer(2, pp, KMeansPlusPlus[float64])
+	assert.NoError(t, err)
+	// Seed away from the outliers so both blobs are found
+	cc[0].Center = observationValues[float64]{1, 1}
+	cc[1].Center = observationValues[float64]{9, 1}
+	r, err := cc.FitTrimmed(pp, 0.019, DefaultFitOptions)
+	assert.NoError(t, err)
+	assert.True(t, r.Converged)
+	assert.Len(t, r.Outliers, 4)
+	for _, o := range outliers {
+		assert.Contains(t, r.Outliers, observationValues[float64](o))
+	}
+	for _, center := range centers {
+		c := cc[cc.Nearest(observationValues[float64](center))]
+		assert.InDelta(t, center[0], c.Center.Values(0), 0.5)
+		assert.InDelta(t, center[1], c.Center.Values(1), 0.5)
+		assert.Len(t, c.Observations.ClusterObservations, 100)
+	}
+
+	_, err = cc.FitTrimmed(pp, 1, DefaultFitOptions)
+	assert.ErrorIs(t, err, ErrInvalidTrim)
+}
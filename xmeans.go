@@ -0,0 +1,92 @@
+package kmeans
+
+import (
+	"fmt"
+	"math"
+)
+
+var ErrInvalidKRange = fmt.Errorf("the maximum k must be greater than or equal to the minimum k")
+
+// XMeansOptions controls the search performed by XMeans
+type XMeansOptions struct {
+	// Trials is the number of 2-means attempts when splitting each cluster
+	Trials int
+	// Fit controls the refinement of the clusters and the splits
+	Fit FitOptions
+}
+
+// DefaultXMeansOptions are reasonable settings for most datasets. A zero Trials is
+// replaced with the default.
+var DefaultXMeansOptions = XMeansOptions{
+	Trials: 3,
+	Fit:    DefaultFitOptions,
+}
+
+// XMeans chooses the number of clusters between minK and maxK using the Bayesian Information
+// Criterion. Starting from minK clusters seeded with KMeansPlusPlus, every cluster is split in two
+// and the split is kept when the two clusters have a lower BIC than the original cluster. The
+// clusters are refined with Fit after every round of splits until no split improves the BIC or
+// maxK is reached. The clusters are returned along with the chosen k.
+func XMeans[T Number](minK, maxK int, dataset Observations[T], opts XMeansOptions) (Clusters[T], int, error) {
+	if minK == 0 {
+		return nil, 0, ErrKMustBeGreaterThanZero
+	}
+	if maxK < minK {
+		return nil, 0, ErrInvalidKRange
+	}
+	if opts.Trials <= 0 {
+		opts.Trials = DefaultXMeansOptions.Trials
+	}
+	cc, err := NewWithInitializer(minK, dataset, KMeansPlusPlus[T])
+	if err != nil {
+		return nil, 0, err
+	}
+	if len(cc) < minK {
+		return nil, 0, ErrTooFewObservations
+	}
+	if _, err := cc.Fit(dataset, opts.Fit); err != nil {
+		return nil, 0, err
+	}
+
+	cc, err = splitRounds(cc, dataset, maxK, opts.Trials, opts.Fit, func(cl Cluster[T], halves Clusters[T]) bool {
+		return halves.BIC() < (Clusters[T]{cl}).BIC()
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+	return cc, len(cc), nil
+}
+
+// BIC returns the Bayesian Information Criterion of the clusters modeled as spherical
+// gaussians sharing a variance, as described by Pelleg and Moore for X-means. As with
+// GaussianMixture.BIC lower values are better and, unlike SumClusterVariance, clusterings
+// with different values of k can be compared.
+func (c Clusters[T]) BIC() float64 {
+	if len(c) == 0 {
+		return math.Inf(1)
+	}
+	degree := float64(c[0].Observations.d)
+	k := float64(len(c))
+	var r float64
+	for _, cl := range c {
+		r += float64(len(cl.Observations.ClusterObservations))
+	}
+	if r <= k {
+		return math.Inf(1)
+	}
+	// Maximum likelihood variance of each dimension shared by all clusters
+	variance := max(c.SumClusterVariance()/(degree*(r-k)), math.SmallestNonzeroFloat64)
+
+	// The squared distances of the observations from their centers contribute
+	// SumClusterVariance / (2 * variance) in total
+	logLikelihood := -degree * (r - k) / 2
+	for _, cl := range c {
+		n := float64(len(cl.Observations.ClusterObservations))
+		if n == 0 {
+			continue
+		}
+		logLikelihood += n*math.Log(n/r) - n*degree/2*math.Log(2*math.Pi*variance)
+	}
+	parameters := (k - 1) + k*degree + 1
+	return -2*logLikelihood + parameters*math.Log(r)
+}
@@ -0,0 +1,51 @@
+package kmeans
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestXMeans(t *testing.T) {
+	pp := blobs([][]float64{{0, 0}, {10, 0}, {20, 0}, {30, 0}}, 40, 1)
+	cc, k, err := XMeans(1, 10, pp, DefaultXMeansOptions)
+	assert.NoError(t, err)
+	assert.Equal(t, 4, k)
+	assert.Len(t, cc, k)
+	for _, cl := range cc {
+		assert.Len(t, cl.Observations.ClusterObservations, 40)
+	}
+
+	cc, k, err = XMeans(1, 2, pp, DefaultXMeansOptions)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, k)
+	assert.Len(t, cc, 2)
+
+	_, _, err = XMeans(3, 2, pp, DefaultXMeansOptions)
+	assert.ErrorIs(t, err, ErrInvalidKRange)
+	_, _, err = XMeans(0, 2, pp, DefaultXMeansOptions)
+	assert.ErrorIs(t, err, ErrKMustBeGreaterThanZero)
+	_, _, err = XMeans(3, 5, points{{0, 0}, {1, 1}}, DefaultXMeansOptions)
+	assert.ErrorIs(t, err, ErrTooFewObservations)
+}
+
+func TestBIC(t *testing.T) {
+	pp := blobs([][]float64{{0, 0}, {10, 0}, {0, 10}}, 40, 1)
+	bic := make(map[int]float64)
+	for k := 1; k <= 3; k++ {
+		// Keep the best of several clusterings to avoid local minima
+		variance := -1.0
+		for range 5 {
+			cc, err := NewWithInitializer(k, pp, KMeansPlusPlus[float64])
+			assert.NoError(t, err)
+			_, err = cc.Fit(pp, DefaultFitOptions)
+			assert.NoError(t, err)
+			if v := cc.SumClusterVariance(); variance < 0 || v < variance {
+				variance = v
+				bic[k] = cc.BIC()
+			}
+		}
+	}
+	assert.Less(t, bic[3], bic[2])
+	assert.Less(t, bic[2], bic[1])
+}